@@ -30,7 +30,7 @@ func makeCamera() *camera.Camera {
 		ImagePixelHeight: 360,
 		LookFrom:         core.NewVec3(278, 273, -800),
 		LookAt:           core.NewVec3(278, 273, 0),
-		Antialiasing:     40,
+		Antialiasing:     10,
 		ProgressChan:     log.NewProgressBar(),
 		NumRenderThreads: runtime.NumCPU(),
 	}
@@ -48,7 +48,9 @@ func makeScene() scene.Scene {
 	objects = append(objects, makeTallBlock()...)
 
 	background := background.NewFlatColor(color.Black)
-	return scene.New(objects, background)
+	return scene.New(objects, background,
+		scene.DirectLightSampling(randomizer),
+		scene.RussianRoulette(3, 0.05, randomizer))
 }
 
 func makeBox() []scene.Object {
@@ -98,11 +100,12 @@ func makeBox() []scene.Object {
 }
 
 func makeTopLight() scene.Object {
+	// Slightly below the ceiling, so that rays don't hit the ceiling instead of the light
 	topLight := geometries.NewQuad(
-		core.NewVec3(343.0, 548.8, 227.0),
-		core.NewVec3(343.0, 548.8, 332.0),
-		core.NewVec3(213.0, 548.8, 332.0),
-		core.NewVec3(213.0, 548.8, 227.0))
+		core.NewVec3(343.0, 548.7, 227.0),
+		core.NewVec3(343.0, 548.7, 332.0),
+		core.NewVec3(213.0, 548.7, 332.0),
+		core.NewVec3(213.0, 548.7, 227.0))
 	topLightMaterial := materials.NewDiffusiveLight(color.White, 10.)
 	return scene.Object{Hittable: topLight, Material: topLightMaterial}
 }
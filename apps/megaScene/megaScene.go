@@ -7,16 +7,19 @@ import (
 	"github.com/Shamanskiy/go-ray-tracer/src/camera/log"
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/noise"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/background"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
 )
 
 var randomizer = random.NewRandomGenerator()
 
 const SMALL_SPHERE_GRID_SIZE = 11
+const FLOOR_NOISE_SEED = 1
 
 func main() {
 	scene := makeScene()
@@ -29,7 +32,8 @@ func makeScene() scene.Scene {
 	objects := []scene.Object{}
 
 	floor := geometries.NewSphere(core.NewVec3(0, -500, 0), 500)
-	floorMaterial := materials.NewDiffusive(color.GrayMedium, randomizer)
+	floorTexture := textures.NewMarble(noise.NewPerlin(FLOOR_NOISE_SEED), 2, 4, color.GrayMedium, color.GrayLight)
+	floorMaterial := materials.NewDiffusiveTextured(floorTexture, randomizer)
 	objects = append(objects, scene.Object{Hittable: floor, Material: floorMaterial})
 
 	sun := geometries.NewSphere(core.NewVec3(100, 200, 100), 50)
@@ -52,7 +56,9 @@ func makeScene() scene.Scene {
 	objects = append(objects, makeGridOfRandomSpheres(SMALL_SPHERE_GRID_SIZE, bigSpheres)...)
 
 	background := background.NewVerticalGradient(color.White, color.SkyBlue)
-	return scene.New(objects, background)
+	megaScene := scene.New(objects, background)
+	log.Printf("scene BVH: %v", megaScene.BVHStats())
+	return megaScene
 }
 
 func makeGridOfRandomSpheres(gridSize int, bigSpheres []geometries.Sphere) []scene.Object {
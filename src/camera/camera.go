@@ -32,7 +32,11 @@ type CameraSettings struct {
 	Antialiasing        int
 	DefocusBlurStrength core.Real
 	NumRenderThreads    int
-	ProgressChan        chan<- log.ProgressUpdate
+	// Rays are traced at random times between the shutter opening and closing,
+	// so that moving objects are blurred along their motion.
+	ShutterOpen  core.Real
+	ShutterClose core.Real
+	ProgressChan chan<- log.ProgressUpdate
 }
 
 func NewCamera(settings *CameraSettings, randomizer random.RandomGenerator) *Camera {
@@ -75,6 +79,9 @@ func validateSettings(settings *CameraSettings) {
 	if settings.DefocusBlurStrength < 0. {
 		panic(fmt.Errorf("new camera: invalid defocus blur strength: %v", settings.DefocusBlurStrength))
 	}
+	if settings.ShutterClose < settings.ShutterOpen {
+		panic(fmt.Errorf("new camera: shutter closes at %v before opening at %v", settings.ShutterClose, settings.ShutterOpen))
+	}
 }
 
 func (c *Camera) Render(scene scene.Scene) *image.Image {
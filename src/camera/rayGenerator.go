@@ -3,7 +3,6 @@ package camera
 import (
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
-	"github.com/chewxy/math32"
 )
 
 var globalUp = core.NewVec3(0, 1, 0)
@@ -19,12 +18,14 @@ type RayGenerator struct {
 
 	randomizer          random.RandomGenerator
 	defocusBlurStrength core.Real
+	shutterOpen         core.Real
+	shutterDuration     core.Real
 }
 
 func NewRayGenerator(settings *CameraSettings, randomizer random.RandomGenerator) *RayGenerator {
-	verticalFOVInRadians := settings.VerticalFOV * math32.Pi / 180
+	verticalFOVInRadians := settings.VerticalFOV * core.Pi / 180
 
-	halfHeight := math32.Tan(verticalFOVInRadians / 2)
+	halfHeight := core.Tan(verticalFOVInRadians / 2)
 	halfWidth := settings.AspectRatio * halfHeight
 
 	back := settings.LookFrom.Sub(settings.LookAt).Normalize()
@@ -43,6 +44,8 @@ func NewRayGenerator(settings *CameraSettings, randomizer random.RandomGenerator
 		up:                  up,
 		randomizer:          randomizer,
 		defocusBlurStrength: settings.DefocusBlurStrength,
+		shutterOpen:         settings.ShutterOpen,
+		shutterDuration:     settings.ShutterClose - settings.ShutterOpen,
 	}
 }
 
@@ -54,7 +57,14 @@ func (r *RayGenerator) GenerateRay(u, v core.Real) core.Ray {
 	}
 	rayDirection := focusPlanePoint.Sub(cameraOrigin)
 
-	return core.NewRay(cameraOrigin, rayDirection)
+	return core.NewRayAtTime(cameraOrigin, rayDirection, r.randomTime())
+}
+
+func (r *RayGenerator) randomTime() core.Real {
+	if r.shutterDuration == 0 {
+		return r.shutterOpen
+	}
+	return r.shutterOpen + r.randomizer.Real()*r.shutterDuration
 }
 
 func (r *RayGenerator) randomOriginOffset() core.Vec3 {
@@ -33,3 +33,33 @@ func (box Box) Max() Vec3 {
 func (box Box) Union(other Box) Box {
 	return NewBox(Vec3Min(box.min, other.min), Vec3Max(box.max, other.max))
 }
+
+func (box Box) Empty() bool {
+	return box.min.X() > box.max.X() || box.min.Y() > box.max.Y() || box.min.Z() > box.max.Z()
+}
+
+// Finite boxes are neither empty nor unbounded, unlike the boxes of planes.
+func (box Box) Finite() bool {
+	for _, value := range []Real{box.min.X(), box.min.Y(), box.min.Z(), box.max.X(), box.max.Y(), box.max.Z()} {
+		if IsInf(value, 0) || IsNaN(value) {
+			return false
+		}
+	}
+	return !box.Empty()
+}
+
+func (box Box) Center() Vec3 {
+	return box.min.Add(box.max).Mul(0.5)
+}
+
+func (box Box) SurfaceArea() Real {
+	if box.Empty() {
+		return 0
+	}
+	extent := box.max.Sub(box.min)
+	return 2 * (extent.X()*extent.Y() + extent.Y()*extent.Z() + extent.Z()*extent.X())
+}
+
+func (box Box) ExtendTo(point Vec3) Box {
+	return NewBox(Vec3Min(box.min, point), Vec3Max(box.max, point))
+}
@@ -4,8 +4,6 @@ import (
 	rgba "image/color"
 
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
-	"github.com/chewxy/math32"
-	"github.com/go-gl/mathgl/mgl32"
 )
 
 var Red = New(1.0, 0.0, 0.0)
@@ -20,15 +18,15 @@ var Golden = New(0.8, 0.6, 0.2)
 var Yellow = New(1.0, 1.0, 0.2)
 
 type Color struct {
-	vec mgl32.Vec3
+	vec core.Vec3
 }
 
 func New(r, g, b core.Real) Color {
-	return Color{vec: mgl32.Vec3{r, g, b}}
+	return Color{vec: core.NewVec3(r, g, b)}
 }
 
 func FromVec3(vec core.Vec3) Color {
-	return New(vec.X(), vec.Y(), vec.Z())
+	return Color{vec: vec}
 }
 
 func (c Color) R() core.Real {
@@ -59,17 +57,39 @@ func (c Color) Div(scalar core.Real) Color {
 	return New(c.R()/scalar, c.G()/scalar, c.B()/scalar)
 }
 
+func (c Color) MaxComponent() core.Real {
+	return core.Max(c.R(), core.Max(c.G(), c.B()))
+}
+
 func (c Color) ToRGBA() rgba.RGBA {
 	return rgba.RGBA{toZero255(c.R()), toZero255(c.G()), toZero255(c.B()), 255}
 }
 
+// FromRGBA is the inverse of ToRGBA, it undoes the gamma correction.
+func FromRGBA(c rgba.Color) Color {
+	r, g, b, _ := c.RGBA()
+	return New(inverseGammaCorrection(r), inverseGammaCorrection(g), inverseGammaCorrection(b))
+}
+
+// FromRGBALinear converts the channels as they are. It's meant for data
+// stored in images, such as normal maps, that isn't gamma corrected.
+func FromRGBALinear(c rgba.Color) Color {
+	r, g, b, _ := c.RGBA()
+	return New(core.Real(r)/0xffff, core.Real(g)/0xffff, core.Real(b)/0xffff)
+}
+
+func inverseGammaCorrection(channel uint32) core.Real {
+	x := core.Real(channel) / 0xffff
+	return x * x
+}
+
 func toZero255(x core.Real) uint8 {
 	x = core.Min(x, 1.)
-	return uint8(math32.Floor(255.99 * gammaCorrection(x)))
+	return uint8(core.Floor(255.99 * gammaCorrection(x)))
 }
 
 func gammaCorrection(input core.Real) core.Real {
-	return math32.Sqrt(input)
+	return core.Sqrt(input)
 }
 
 func Interpolate(A, B Color, t core.Real) Color {
@@ -0,0 +1,156 @@
+package core
+
+// Mat4 is a 4x4 matrix of an affine transformation in homogeneous coordinates.
+type Mat4 struct {
+	mat mat4
+}
+
+func Identity() Mat4 {
+	return Mat4{ident4()}
+}
+
+func Translation(offset Vec3) Mat4 {
+	return Mat4{translate3D(offset.X(), offset.Y(), offset.Z())}
+}
+
+func Scaling(factors Vec3) Mat4 {
+	return Mat4{scale3D(factors.X(), factors.Y(), factors.Z())}
+}
+
+// Rotation around an axis through the origin, the angle is in degrees.
+func Rotation(angle Real, axis Vec3) Mat4 {
+	angleInRadians := angle * Pi / 180
+	return Mat4{homogRotate3D(angleInRadians, axis.Normalize().vec)}
+}
+
+func (m Mat4) At(row, column int) Real {
+	return m.mat.At(row, column)
+}
+
+// Mul composes two transformations, the other one is applied first.
+func (m Mat4) Mul(other Mat4) Mat4 {
+	return Mat4{m.mat.Mul4(other.mat)}
+}
+
+func (m Mat4) Transpose() Mat4 {
+	return Mat4{m.mat.Transpose()}
+}
+
+// Inverse returns a zero matrix if the matrix is singular.
+func (m Mat4) Inverse() Mat4 {
+	return Mat4{m.mat.Inv()}
+}
+
+func (m Mat4) Invertible() bool {
+	return m.mat.Det() != 0
+}
+
+func (m Mat4) TransformPoint(point Vec3) Vec3 {
+	return Vec3{transformCoordinate(point.vec, m.mat)}
+}
+
+// TransformDirection ignores the translation part.
+func (m Mat4) TransformDirection(direction Vec3) Vec3 {
+	return Vec3{transformNormal(direction.vec, m.mat)}
+}
+
+// TransformBox returns the smallest axis-aligned box containing the transformed box.
+// https://www.realtimerendering.com/resources/GraphicsGems/gems/TransBox.c
+func (m Mat4) TransformBox(box Box) Box {
+	if box.Empty() {
+		return box
+	}
+
+	var newMin, newMax [3]Real
+	for row := 0; row < 3; row++ {
+		newMin[row] = m.At(row, 3)
+		newMax[row] = m.At(row, 3)
+		for column := 0; column < 3; column++ {
+			factor := m.At(row, column)
+			if factor == 0 {
+				// Avoids 0 * Inf = NaN for unbounded boxes
+				continue
+			}
+			a := factor * box.min.At(column)
+			b := factor * box.max.At(column)
+			newMin[row] += Min(a, b)
+			newMax[row] += Max(a, b)
+		}
+	}
+	return NewBox(NewVec3(newMin[0], newMin[1], newMin[2]), NewVec3(newMax[0], newMax[1], newMax[2]))
+}
+
+// Columns of shear-free matrices are orthogonal up to this cosine, after rounding.
+const maxShearCosine = 1e-4
+
+// Decomposition splits an affine transformation into a translation, a rotation and a scaling,
+// applied in reverse order. Decompositions are blended instead of matrices, so that rotating
+// objects keep their shape.
+// https://www.pbr-book.org/3ed-2018/Geometry_and_Transformations/Animating_Transformations
+type Decomposition struct {
+	translation Vec3
+	rotation    quat
+	scale       Vec3
+}
+
+// Decompose fails for matrices with a shear, which can't be split this way. Mirroring matrices
+// keep the mirroring in the scale of the X axis. The matrix must be invertible.
+func (m Mat4) Decompose() (Decomposition, bool) {
+	translation := NewVec3(m.At(0, 3), m.At(1, 3), m.At(2, 3))
+
+	var columns [3]vec3
+	var scale [3]Real
+	for column := 0; column < 3; column++ {
+		columns[column] = m.mat.Col(column).Vec3()
+		scale[column] = columns[column].Len()
+		columns[column] = columns[column].Mul(1 / scale[column])
+	}
+	for column := 0; column < 3; column++ {
+		if Abs(columns[column].Dot(columns[(column+1)%3])) > maxShearCosine {
+			return Decomposition{}, false
+		}
+	}
+	if columns[0].Dot(columns[1].Cross(columns[2])) < 0 {
+		scale[0] = -scale[0]
+		columns[0] = columns[0].Mul(-1)
+	}
+
+	rotation := mat4ToQuat(mat3FromCols(columns[0], columns[1], columns[2]).Mat4())
+	return Decomposition{translation: translation, rotation: rotation, scale: NewVec3(scale[0], scale[1], scale[2])}, true
+}
+
+// Interpolate blends two decompositions at t from 0 to 1.
+func (d Decomposition) Interpolate(other Decomposition, t Real) Decomposition {
+	return Decomposition{
+		translation: d.translation.Add(other.translation.Sub(d.translation).Mul(t)),
+		rotation:    quatSlerp(d.rotation, d.nearRotation(other), t),
+		scale:       d.scale.Add(other.scale.Sub(d.scale).Mul(t)),
+	}
+}
+
+// The slerp takes the long way around if the quaternions are in opposite hemispheres.
+func (d Decomposition) nearRotation(other Decomposition) quat {
+	if d.rotation.Dot(other.rotation) < 0 {
+		return other.rotation.Scale(-1)
+	}
+	return other.rotation
+}
+
+// RotationAngle returns the angle in radians that Interpolate rotates by from this decomposition to the other one.
+func (d Decomposition) RotationAngle(other Decomposition) Real {
+	return 2 * Acos(Min(1, d.rotation.Dot(d.nearRotation(other))))
+}
+
+func (d Decomposition) Scale() Vec3 {
+	return d.scale
+}
+
+// Matrices returns the transformation and its inverse, which is composed from
+// the inverted parts rather than computed from the matrix.
+func (d Decomposition) Matrices() (Mat4, Mat4) {
+	rotation := Mat4{d.rotation.Mat4()}
+	inverseScale := NewVec3(1/d.scale.X(), 1/d.scale.Y(), 1/d.scale.Z())
+	transform := Translation(d.translation).Mul(rotation).Mul(Scaling(d.scale))
+	inverse := Scaling(inverseScale).Mul(rotation.Transpose()).Mul(Translation(d.translation.Mul(-1)))
+	return transform, inverse
+}
@@ -0,0 +1,100 @@
+package noise
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+)
+
+const permutationSize = 256
+
+// Perlin is improved 3D gradient noise. The permutation table is shuffled from a seed,
+// so that the same seed always produces the same noise.
+// https://mrl.cs.nyu.edu/~perlin/paper445.pdf
+type Perlin struct {
+	permutation [2 * permutationSize]int // doubled to avoid wrapping the hash indices
+}
+
+func NewPerlin(seed int64) *Perlin {
+	generator := rand.New(rand.NewSource(seed))
+	perlin := &Perlin{}
+	for i, value := range generator.Perm(permutationSize) {
+		perlin.permutation[i] = value
+		perlin.permutation[i+permutationSize] = value
+	}
+	return perlin
+}
+
+// Noise returns a smooth value in [-1, 1], which is zero at the integer lattice points.
+func (p *Perlin) Noise(point core.Vec3) core.Real {
+	floorX, floorY, floorZ := core.Floor(point.X()), core.Floor(point.Y()), core.Floor(point.Z())
+	x, y, z := point.X()-floorX, point.Y()-floorY, point.Z()-floorZ
+	cellX, cellY, cellZ := latticeIndex(floorX), latticeIndex(floorY), latticeIndex(floorZ)
+
+	perm := &p.permutation
+	a := perm[cellX] + cellY
+	aa, ab := perm[a]+cellZ, perm[a+1]+cellZ
+	b := perm[cellX+1] + cellY
+	ba, bb := perm[b]+cellZ, perm[b+1]+cellZ
+
+	u, v, w := fade(x), fade(y), fade(z)
+	return lerp(w,
+		lerp(v,
+			lerp(u, gradient(perm[aa], x, y, z), gradient(perm[ba], x-1, y, z)),
+			lerp(u, gradient(perm[ab], x, y-1, z), gradient(perm[bb], x-1, y-1, z))),
+		lerp(v,
+			lerp(u, gradient(perm[aa+1], x, y, z-1), gradient(perm[ba+1], x-1, y, z-1)),
+			lerp(u, gradient(perm[ab+1], x, y-1, z-1), gradient(perm[bb+1], x-1, y-1, z-1))))
+}
+
+// FBM sums octaves of noise with doubling frequencies and halving amplitudes.
+// The sum is normalized to [-1, 1].
+func (p *Perlin) FBM(point core.Vec3, octaves int) core.Real {
+	return p.sumOctaves(point, octaves, p.Noise)
+}
+
+// Turbulence is FBM of the absolute noise values, it lies in [0, 1] and has sharp creases
+// where the noise changes sign.
+func (p *Perlin) Turbulence(point core.Vec3, octaves int) core.Real {
+	return p.sumOctaves(point, octaves, func(point core.Vec3) core.Real {
+		return core.Abs(p.Noise(point))
+	})
+}
+
+func (p *Perlin) sumOctaves(point core.Vec3, octaves int, octave func(core.Vec3) core.Real) core.Real {
+	if octaves < 1 {
+		panic(fmt.Errorf("invalid number of noise octaves: %d", octaves))
+	}
+
+	sum, amplitudeSum := core.Real(0), core.Real(0)
+	amplitude := core.Real(1)
+	for i := 0; i < octaves; i++ {
+		sum += amplitude * octave(point)
+		amplitudeSum += amplitude
+		amplitude *= 0.5
+		point = point.Mul(2)
+	}
+	return sum / amplitudeSum
+}
+
+func latticeIndex(floor core.Real) int {
+	return int(floor) & (permutationSize - 1)
+}
+
+// Smoothstep with zero first and second derivatives at 0 and 1.
+func fade(t core.Real) core.Real {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(t, a, b core.Real) core.Real {
+	return a + t*(b-a)
+}
+
+// Dot product of the offset with one of the 12 gradients pointing to the cube edge centers.
+func gradient(hash int, x, y, z core.Real) core.Real {
+	h := hash & 15
+	u := core.IfElse(h < 8, x, y)
+	v := core.IfElse(h < 4, y, core.IfElse(h == 12 || h == 14, x, z))
+	return core.IfElse(h&1 == 0, u, -u) + core.IfElse(h&2 == 0, v, -v)
+}
@@ -1,9 +1,5 @@
 package core
 
-import (
-	"github.com/chewxy/math32"
-)
-
 type QuadEqSolution struct {
 	Left       Real
 	Right      Real
@@ -13,7 +9,7 @@ type QuadEqSolution struct {
 func SolveQuadEquation(a, b, c Real) QuadEqSolution {
 	d := b*b - 4*a*c
 	if d >= 0 {
-		dSqrt := math32.Sqrt(d)
+		dSqrt := Sqrt(d)
 		left := (-b - dSqrt) / (2 * a)
 		right := (-b + dSqrt) / (2 * a)
 		return QuadEqSolution{Left: left, Right: right, NoSolution: false}
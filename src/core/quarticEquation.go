@@ -0,0 +1,116 @@
+package core
+
+import (
+	"math"
+	"sort"
+)
+
+// Newton steps that refine every root against the original equation.
+const quarticPolishSteps = 3
+
+// QuarticEqSolution holds the real roots in ascending order, repeated roots may be listed more than once.
+type QuarticEqSolution struct {
+	Roots [4]Real
+	Count int
+}
+
+// SolveQuarticEquation finds the real roots of a*x^4 + b*x^3 + c*x^2 + d*x + e = 0, a must not be zero.
+// Ferrari's method splits the quartic into two quadratics using a root of the resolvent cubic.
+// It's computed in float64 and the roots are polished with Newton's method, since
+// the intermediate terms cancel badly in float32.
+// https://en.wikipedia.org/wiki/Quartic_function#Ferrari's_solution
+func SolveQuarticEquation(a, b, c, d, e Real) QuarticEqSolution {
+	A := float64(b) / float64(a)
+	B := float64(c) / float64(a)
+	C := float64(d) / float64(a)
+	D := float64(e) / float64(a)
+
+	// Depressed quartic y^4 + p*y^2 + q*y + r = 0 with x = y - A/4
+	AA := A * A
+	p := B - 3*AA/8
+	q := C - A*B/2 + AA*A/8
+	r := D - A*C/4 + AA*B/16 - 3*AA*AA/256
+
+	var roots []float64
+	if math.Abs(q) < 1e-12 {
+		// Biquadratic, a quadratic in y^2
+		for _, z := range solveQuad64(1, p, r) {
+			if z >= 0 {
+				roots = append(roots, math.Sqrt(z), -math.Sqrt(z))
+			}
+		}
+	} else {
+		// The resolvent cubic has a positive root for q != 0, which makes
+		// (y^2 + p/2 + m)^2 = 2m*y^2 - q*y + m^2 + m*p + p^2/4 - r a difference of squares
+		m := largestCubicRoot(p, p*p/4-r, -q*q/8)
+		s := math.Sqrt(2 * m)
+		roots = append(roots, solveQuad64(1, -s, p/2+m+q/(2*s))...)
+		roots = append(roots, solveQuad64(1, s, p/2+m-q/(2*s))...)
+	}
+
+	solution := QuarticEqSolution{}
+	for _, y := range roots {
+		x := polishQuarticRoot(y-A/4, A, B, C, D)
+		solution.Roots[solution.Count] = Real(x)
+		solution.Count++
+	}
+	sort.Slice(solution.Roots[:solution.Count], func(i, j int) bool {
+		return solution.Roots[i] < solution.Roots[j]
+	})
+	return solution
+}
+
+// The real roots of a*x^2 + b*x + c = 0, computed without cancellation.
+func solveQuad64(a, b, c float64) []float64 {
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return nil
+	}
+	q := -(b + math.Copysign(math.Sqrt(discriminant), b)) / 2
+	if q == 0 {
+		return []float64{0, 0}
+	}
+	return []float64{q / a, c / q}
+}
+
+// The largest real root of x^3 + a*x^2 + b*x + c = 0.
+// https://en.wikipedia.org/wiki/Cubic_equation#Trigonometric_and_hyperbolic_solutions
+func largestCubicRoot(a, b, c float64) float64 {
+	Q := (a*a - 3*b) / 9
+	R := (2*a*a*a - 9*a*b + 27*c) / 54
+
+	var root float64
+	if R*R < Q*Q*Q {
+		theta := math.Acos(R / math.Sqrt(Q*Q*Q))
+		root = -2*math.Sqrt(Q)*math.Cos((theta+2*math.Pi)/3) - a/3
+	} else {
+		S := -math.Copysign(math.Cbrt(math.Abs(R)+math.Sqrt(R*R-Q*Q*Q)), R)
+		T := 0.
+		if S != 0 {
+			T = Q / S
+		}
+		root = S + T - a/3
+	}
+
+	for i := 0; i < quarticPolishSteps; i++ {
+		value := ((root+a)*root+b)*root + c
+		derivative := (3*root+2*a)*root + b
+		if derivative == 0 {
+			break
+		}
+		root -= value / derivative
+	}
+	return root
+}
+
+func polishQuarticRoot(x, a, b, c, d float64) float64 {
+	for i := 0; i < quarticPolishSteps; i++ {
+		value := (((x+a)*x+b)*x+c)*x + d
+		derivative := ((4*x+3*a)*x+2*b)*x + c
+		if derivative == 0 {
+			break
+		}
+		x -= value / derivative
+	}
+	return x
+}
@@ -22,6 +22,10 @@ func (f FakeRandomGenerator) Vec3InUnitSphere() core.Vec3 {
 	return core.NewVec3(0, 0, 0)
 }
 
+func (f FakeRandomGenerator) Vec3OnUnitSphere() core.Vec3 {
+	return core.NewVec3(0, 0, 0)
+}
+
 func (FakeRandomGenerator) Vec3InUnitDisk() core.Vec3 {
 	return core.NewVec3(0, 0, 0)
 }
@@ -10,6 +10,7 @@ type RandomGenerator interface {
 	Real() core.Real
 	Vec3() core.Vec3
 	Vec3InUnitSphere() core.Vec3
+	Vec3OnUnitSphere() core.Vec3
 	Vec3InUnitDisk() core.Vec3
 }
 
@@ -21,12 +22,17 @@ func NewRandomGenerator() RandomGenerator {
 
 // https://qqq.ninja/blog/post/fast-threadsafe-randomness-in-go/
 func (r RandomGeneratedImpl) Real() core.Real {
-	outUint64 := new(maphash.Hash).Sum64()
-	outFloat64 := float64(outUint64) / float64(1<<64)
-	if outFloat64 >= 1 {
-		outFloat64 = 0.
+	return UnitReal(new(maphash.Hash).Sum64())
+}
+
+// UnitReal maps random bits uniformly to [0, 1).
+func UnitReal(bits uint64) core.Real {
+	// Values just below one round up to one when converted to Real
+	out := core.Real(float64(bits) / float64(1<<64))
+	if out >= 1 {
+		return 0
 	}
-	return float32(outFloat64)
+	return out
 }
 
 func (r RandomGeneratedImpl) Vec3() core.Vec3 {
@@ -47,6 +53,15 @@ func (r RandomGeneratedImpl) Vec3InUnitSphere() core.Vec3 {
 	return vec
 }
 
+func (r RandomGeneratedImpl) Vec3OnUnitSphere() core.Vec3 {
+	vec := r.Vec3InUnitSphere()
+	// Vectors too close to the origin lose their direction when normalized
+	for vec.LenSqr() < core.Tolerance {
+		vec = r.Vec3InUnitSphere()
+	}
+	return vec.Normalize()
+}
+
 func (r RandomGeneratedImpl) Vec3InUnitDisk() core.Vec3 {
 	unitDiagVec2 := core.NewVec3(1, 1, 0)
 
@@ -3,10 +3,16 @@ package core
 type Ray struct {
 	origin    Vec3
 	direction Vec3
+	time      Real
 }
 
 func NewRay(origin Vec3, direction Vec3) Ray {
-	return Ray{origin, direction}
+	return Ray{origin: origin, direction: direction}
+}
+
+// NewRayAtTime creates a ray that sees moving objects where they are at the given time.
+func NewRayAtTime(origin Vec3, direction Vec3, time Real) Ray {
+	return Ray{origin: origin, direction: direction, time: time}
 }
 
 func (ray Ray) Origin() Vec3 {
@@ -17,6 +23,16 @@ func (ray Ray) Direction() Vec3 {
 	return ray.direction
 }
 
+func (ray Ray) Time() Real {
+	return ray.time
+}
+
+// AtTime returns the same ray traced at another time.
+func (ray Ray) AtTime(time Real) Ray {
+	ray.time = time
+	return ray
+}
+
 func (ray Ray) Eval(t Real) Vec3 {
 	return ray.origin.Add(ray.direction.Mul(t))
 }
@@ -62,3 +78,19 @@ func (ray Ray) Hits(box Box, params Interval) bool {
 
 	return params.max >= params.min
 }
+
+// Clip returns the part of the parameter interval where the ray is inside the box.
+// Unlike Hits, it's not tuned for BVH traversal.
+func (ray Ray) Clip(box Box, params Interval) (Interval, bool) {
+	for axis := 0; axis < 3; axis++ {
+		invD := 1 / ray.direction.At(axis)
+		t0 := (box.min.At(axis) - ray.origin.At(axis)) * invD
+		t1 := (box.max.At(axis) - ray.origin.At(axis)) * invD
+		if invD < 0 {
+			t0, t1 = t1, t0
+		}
+		params.min = Max(params.min, t0)
+		params.max = Min(params.max, t1)
+	}
+	return params, params.min < params.max
+}
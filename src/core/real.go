@@ -2,27 +2,13 @@ package core
 
 import (
 	"fmt"
-
-	"github.com/chewxy/math32"
+	"math"
 )
 
-type Real = float32
-
-func Inf() Real {
-	return math32.Inf(1)
-}
-
-func Abs(v Real) Real {
-	return math32.Abs(v)
-}
-
-func Sqrt(v Real) Real {
-	return math32.Sqrt(v)
-}
-
-func Min(a, b Real) Real {
-	return math32.Min(a, b)
-}
+const (
+	Pi    Real = math.Pi
+	Sqrt2 Real = math.Sqrt2
+)
 
 type Interval struct {
 	min, max Real
@@ -42,3 +28,11 @@ func (i Interval) Contains(x Real) bool {
 func (i Interval) ContainsStrictly(x Real) bool {
 	return x > i.min && x < i.max
 }
+
+func (i Interval) Min() Real {
+	return i.min
+}
+
+func (i Interval) Max() Real {
+	return i.max
+}
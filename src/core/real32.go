@@ -0,0 +1,147 @@
+//go:build !double
+
+package core
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Real is the floating point type of all geometry and color computations.
+// It's float32 by default, building with the double tag switches it to float64,
+// which avoids cracks and self-intersections in scenes with large coordinates:
+//
+//	go build -tags double ./...
+type Real = float32
+
+// RealBitSize is the size of Real in bits, e.g. for strconv.ParseFloat.
+const RealBitSize = 32
+
+// The vector and matrix types backing Vec2, Vec3 and Mat4
+type (
+	vec2 = mgl32.Vec2
+	vec3 = mgl32.Vec3
+	mat3 = mgl32.Mat3
+	mat4 = mgl32.Mat4
+	quat = mgl32.Quat
+)
+
+func Inf() Real {
+	return math32.Inf(1)
+}
+
+func Min(a, b Real) Real {
+	return math32.Min(a, b)
+}
+
+func Max(a, b Real) Real {
+	return math32.Max(a, b)
+}
+
+func Abs(v Real) Real {
+	return math32.Abs(v)
+}
+
+func Sqrt(v Real) Real {
+	return math32.Sqrt(v)
+}
+
+func Floor(v Real) Real {
+	return math32.Floor(v)
+}
+
+func Round(v Real) Real {
+	return math32.Round(v)
+}
+
+func Sin(v Real) Real {
+	return math32.Sin(v)
+}
+
+func Cos(v Real) Real {
+	return math32.Cos(v)
+}
+
+func Tan(v Real) Real {
+	return math32.Tan(v)
+}
+
+func Acos(v Real) Real {
+	return math32.Acos(v)
+}
+
+func Exp(v Real) Real {
+	return math32.Exp(v)
+}
+
+func Log(v Real) Real {
+	return math32.Log(v)
+}
+
+func Sincos(v Real) (Real, Real) {
+	return math32.Sincos(v)
+}
+
+func Atan2(y, x Real) Real {
+	return math32.Atan2(y, x)
+}
+
+func Pow(x, y Real) Real {
+	return math32.Pow(x, y)
+}
+
+func Hypot(x, y Real) Real {
+	return math32.Hypot(x, y)
+}
+
+func Nextafter(x, y Real) Real {
+	return math32.Nextafter(x, y)
+}
+
+func Copysign(magnitude, sign Real) Real {
+	return math32.Copysign(magnitude, sign)
+}
+
+func IsNaN(v Real) bool {
+	return math32.IsNaN(v)
+}
+
+func IsInf(v Real, sign int) bool {
+	return math32.IsInf(v, sign)
+}
+
+func ident4() mat4 {
+	return mgl32.Ident4()
+}
+
+func translate3D(x, y, z Real) mat4 {
+	return mgl32.Translate3D(x, y, z)
+}
+
+func scale3D(x, y, z Real) mat4 {
+	return mgl32.Scale3D(x, y, z)
+}
+
+func homogRotate3D(angle Real, axis vec3) mat4 {
+	return mgl32.HomogRotate3D(angle, axis)
+}
+
+func transformCoordinate(point vec3, m mat4) vec3 {
+	return mgl32.TransformCoordinate(point, m)
+}
+
+func transformNormal(direction vec3, m mat4) vec3 {
+	return mgl32.TransformNormal(direction, m)
+}
+
+func mat3FromCols(col0, col1, col2 vec3) mat3 {
+	return mgl32.Mat3FromCols(col0, col1, col2)
+}
+
+func mat4ToQuat(m mat4) quat {
+	return mgl32.Mat4ToQuat(m)
+}
+
+func quatSlerp(q1, q2 quat, t Real) quat {
+	return mgl32.QuatSlerp(q1, q2, t)
+}
@@ -0,0 +1,144 @@
+//go:build double
+
+package core
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Real is float64 in builds with the double tag, see real32.go.
+type Real = float64
+
+// RealBitSize is the size of Real in bits, e.g. for strconv.ParseFloat.
+const RealBitSize = 64
+
+// The vector and matrix types backing Vec2, Vec3 and Mat4
+type (
+	vec2 = mgl64.Vec2
+	vec3 = mgl64.Vec3
+	mat3 = mgl64.Mat3
+	mat4 = mgl64.Mat4
+	quat = mgl64.Quat
+)
+
+func Inf() Real {
+	return math.Inf(1)
+}
+
+func Min(a, b Real) Real {
+	return math.Min(a, b)
+}
+
+func Max(a, b Real) Real {
+	return math.Max(a, b)
+}
+
+func Abs(v Real) Real {
+	return math.Abs(v)
+}
+
+func Sqrt(v Real) Real {
+	return math.Sqrt(v)
+}
+
+func Floor(v Real) Real {
+	return math.Floor(v)
+}
+
+func Round(v Real) Real {
+	return math.Round(v)
+}
+
+func Sin(v Real) Real {
+	return math.Sin(v)
+}
+
+func Cos(v Real) Real {
+	return math.Cos(v)
+}
+
+func Tan(v Real) Real {
+	return math.Tan(v)
+}
+
+func Acos(v Real) Real {
+	return math.Acos(v)
+}
+
+func Exp(v Real) Real {
+	return math.Exp(v)
+}
+
+func Log(v Real) Real {
+	return math.Log(v)
+}
+
+func Sincos(v Real) (Real, Real) {
+	return math.Sincos(v)
+}
+
+func Atan2(y, x Real) Real {
+	return math.Atan2(y, x)
+}
+
+func Pow(x, y Real) Real {
+	return math.Pow(x, y)
+}
+
+func Hypot(x, y Real) Real {
+	return math.Hypot(x, y)
+}
+
+func Nextafter(x, y Real) Real {
+	return math.Nextafter(x, y)
+}
+
+func Copysign(magnitude, sign Real) Real {
+	return math.Copysign(magnitude, sign)
+}
+
+func IsNaN(v Real) bool {
+	return math.IsNaN(v)
+}
+
+func IsInf(v Real, sign int) bool {
+	return math.IsInf(v, sign)
+}
+
+func ident4() mat4 {
+	return mgl64.Ident4()
+}
+
+func translate3D(x, y, z Real) mat4 {
+	return mgl64.Translate3D(x, y, z)
+}
+
+func scale3D(x, y, z Real) mat4 {
+	return mgl64.Scale3D(x, y, z)
+}
+
+func homogRotate3D(angle Real, axis vec3) mat4 {
+	return mgl64.HomogRotate3D(angle, axis)
+}
+
+func transformCoordinate(point vec3, m mat4) vec3 {
+	return mgl64.TransformCoordinate(point, m)
+}
+
+func transformNormal(direction vec3, m mat4) vec3 {
+	return mgl64.TransformNormal(direction, m)
+}
+
+func mat3FromCols(col0, col1, col2 vec3) mat3 {
+	return mgl64.Mat3FromCols(col0, col1, col2)
+}
+
+func mat4ToQuat(m mat4) quat {
+	return mgl64.Mat4ToQuat(m)
+}
+
+func quatSlerp(q1, q2 quat, t Real) quat {
+	return mgl64.QuatSlerp(q1, q2, t)
+}
@@ -0,0 +1,29 @@
+package core
+
+// SurfacePoint is a point on a surface with its local properties, as seen by materials and textures.
+// The tangent points along increasing U and the bitangent along increasing V. Together with the
+// normal they form an orthonormal frame.
+type SurfacePoint struct {
+	Point     Vec3
+	Normal    Vec3
+	UV        Vec2
+	Tangent   Vec3
+	Bitangent Vec3
+}
+
+// TangentFrame makes the tangent orthogonal to the unit normal and completes the frame with
+// the bitangent, flipped if needed to point the same way as the given one. Falls back to
+// an arbitrary frame if the tangent is degenerate or parallel to the normal.
+func TangentFrame(normal, tangent, bitangent Vec3) (Vec3, Vec3) {
+	orthogonalTangent := tangent.Sub(normal.Mul(normal.Dot(tangent)))
+	if orthogonalTangent.LenSqr() <= Tolerance*tangent.LenSqr() {
+		return OrthonormalBasis(normal)
+	}
+
+	orthogonalTangent = orthogonalTangent.Normalize()
+	orthogonalBitangent := normal.Cross(orthogonalTangent)
+	if orthogonalBitangent.Dot(bitangent) < 0 {
+		orthogonalBitangent = orthogonalBitangent.Mul(-1)
+	}
+	return orthogonalTangent, orthogonalBitangent
+}
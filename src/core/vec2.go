@@ -0,0 +1,34 @@
+package core
+
+// Vec2 holds texture coordinates.
+type Vec2 struct {
+	vec vec2
+}
+
+func NewVec2(x, y Real) Vec2 {
+	return Vec2{vec: vec2{x, y}}
+}
+
+func (vec Vec2) X() Real {
+	return vec.vec.X()
+}
+
+func (vec Vec2) Y() Real {
+	return vec.vec.Y()
+}
+
+func (vecA Vec2) Add(vecB Vec2) Vec2 {
+	return Vec2{vec: vecA.vec.Add(vecB.vec)}
+}
+
+func (vecA Vec2) Sub(vecB Vec2) Vec2 {
+	return Vec2{vec: vecA.vec.Sub(vecB.vec)}
+}
+
+func (vec Vec2) Mul(scalar Real) Vec2 {
+	return Vec2{vec: vec.vec.Mul(scalar)}
+}
+
+func (vecA Vec2) InDelta(vecB Vec2, delta Real) bool {
+	return vecA.vec.Sub(vecB.vec).LenSqr() < delta*delta
+}
@@ -1,18 +1,13 @@
 package core
 
-import (
-	"fmt"
-
-	"github.com/chewxy/math32"
-	"github.com/go-gl/mathgl/mgl32"
-)
+import "fmt"
 
 type Vec3 struct {
-	vec mgl32.Vec3
+	vec vec3
 }
 
 func NewVec3(x, y, z Real) Vec3 {
-	return Vec3{vec: mgl32.Vec3{x, y, z}}
+	return Vec3{vec: vec3{x, y, z}}
 }
 
 func (vec Vec3) X() Real {
@@ -90,12 +85,17 @@ func (vec Vec3) At(i int) Real {
 	}
 }
 
+// Permute returns the vector with its components reordered, X is taken from component x and so on.
+func (vec Vec3) Permute(x, y, z int) Vec3 {
+	return Vec3{vec3{vec.vec[x], vec.vec[y], vec.vec[z]}}
+}
+
 func Vec3Min(a, b Vec3) Vec3 {
-	return NewVec3(math32.Min(a.X(), b.X()), math32.Min(a.Y(), b.Y()), math32.Min(a.Z(), b.Z()))
+	return NewVec3(Min(a.X(), b.X()), Min(a.Y(), b.Y()), Min(a.Z(), b.Z()))
 }
 
 func Vec3Max(a, b Vec3) Vec3 {
-	return NewVec3(math32.Max(a.X(), b.X()), math32.Max(a.Y(), b.Y()), math32.Max(a.Z(), b.Z()))
+	return NewVec3(Max(a.X(), b.X()), Max(a.Y(), b.Y()), Max(a.Z(), b.Z()))
 }
 
 func Normal(a, b, c Vec3) Vec3 {
@@ -103,3 +103,14 @@ func Normal(a, b, c Vec3) Vec3 {
 	edge2 := c.Sub(a)
 	return edge1.Cross(edge2).Normalize()
 }
+
+// OrthonormalBasis returns two unit vectors orthogonal to each other and to the unit normal.
+// https://graphics.pixar.com/library/OrthonormalB/paper.pdf
+func OrthonormalBasis(normal Vec3) (Vec3, Vec3) {
+	sign := Copysign(1, normal.Z())
+	a := -1 / (sign + normal.Z())
+	b := normal.X() * normal.Y() * a
+	tangent := NewVec3(1+sign*normal.X()*normal.X()*a, sign*b, -sign*normal.X())
+	bitangent := NewVec3(b, sign+normal.Y()*normal.Y()*a, -normal.Y())
+	return tangent, bitangent
+}
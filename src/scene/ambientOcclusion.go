@@ -0,0 +1,49 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+// AmbientOcclusion shades surfaces by the fraction of rays leaving them that aren't blocked by
+// other objects within the given radius. The rays are distributed proportionally to the cosine.
+type AmbientOcclusion struct {
+	samples    int
+	radius     core.Real
+	randomizer random.RandomGenerator
+}
+
+func NewAmbientOcclusion(samples int, radius core.Real, randomizer random.RandomGenerator) *AmbientOcclusion {
+	if samples < 1 {
+		panic(fmt.Errorf("invalid ambient occlusion samples: %d", samples))
+	}
+	if radius <= 0 {
+		panic(fmt.Errorf("invalid ambient occlusion radius: %v", radius))
+	}
+	return &AmbientOcclusion{samples: samples, radius: radius, randomizer: randomizer}
+}
+
+func (a *AmbientOcclusion) Integrate(ray core.Ray, scene *SceneImpl) color.Color {
+	optionalHit := scene.Intersect(ray)
+	if optionalHit.Empty() {
+		return scene.BackgroundColor(ray)
+	}
+
+	hit := optionalHit.Value()
+	normal := core.IfElse(hit.Normal.Dot(ray.Direction()) > 0, hit.Normal.Mul(-1), hit.Normal)
+
+	unoccluded := 0
+	for i := 0; i < a.samples; i++ {
+		direction := normal.Add(a.randomizer.Vec3OnUnitSphere())
+		if direction.LenSqr() < core.Tolerance {
+			direction = normal
+		}
+		if !scene.occluded(core.NewRayAtTime(hit.Point, direction.Normalize(), ray.Time()), a.radius) {
+			unoccluded++
+		}
+	}
+	return color.White.Mul(core.Real(unoccluded) / core.Real(a.samples))
+}
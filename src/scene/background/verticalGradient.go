@@ -3,8 +3,6 @@ package background
 import (
 	"fmt"
 
-	"github.com/chewxy/math32"
-
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
 )
@@ -24,7 +22,7 @@ func NewVerticalGradient(bottomColor, topColor color.Color) VerticalGradient {
 func (g VerticalGradient) ColorRay(ray core.Ray) color.Color {
 	normalizedDirection := ray.Direction().Normalize()
 
-	if math32.IsNaN(normalizedDirection.Y()) {
+	if core.IsNaN(normalizedDirection.Y()) {
 		panic(fmt.Errorf("got ray with zero length direction"))
 	}
 
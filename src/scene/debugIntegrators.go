@@ -0,0 +1,45 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+)
+
+// DebugNormals maps the normal at the closest hit from [-1, 1] to RGB colors in [0, 1].
+type DebugNormals struct{}
+
+func NewDebugNormals() DebugNormals {
+	return DebugNormals{}
+}
+
+func (DebugNormals) Integrate(ray core.Ray, scene *SceneImpl) color.Color {
+	optionalHit := scene.Intersect(ray)
+	if optionalHit.Empty() {
+		return color.Black
+	}
+	normal := optionalHit.Value().Normal
+	return color.FromVec3(normal.Add(core.NewVec3(1, 1, 1)).Mul(0.5))
+}
+
+// DebugDepth shades hits from white at the ray origin to black at the max depth and beyond.
+type DebugDepth struct {
+	maxDepth core.Real
+}
+
+func NewDebugDepth(maxDepth core.Real) DebugDepth {
+	if maxDepth <= 0 {
+		panic(fmt.Errorf("invalid debug max depth: %v", maxDepth))
+	}
+	return DebugDepth{maxDepth: maxDepth}
+}
+
+func (d DebugDepth) Integrate(ray core.Ray, scene *SceneImpl) color.Color {
+	optionalHit := scene.Intersect(ray)
+	if optionalHit.Empty() {
+		return color.Black
+	}
+	depth := optionalHit.Value().Param * ray.Direction().Len()
+	return color.White.Mul(1 - core.Min(depth/d.maxDepth, 1))
+}
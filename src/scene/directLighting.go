@@ -0,0 +1,66 @@
+package scene
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+)
+
+// DirectLighting accounts only for light reaching surfaces straight from light sources and the background.
+// Rays are followed through mirrors and glass, since their discrete directions can't be sampled otherwise.
+type DirectLighting struct {
+	randomizer        random.RandomGenerator
+	maxRayReflections int
+}
+
+func NewDirectLighting(randomizer random.RandomGenerator, settings ...DirectLightingSetting) *DirectLighting {
+	directLighting := &DirectLighting{
+		randomizer:        randomizer,
+		maxRayReflections: DEFAULT_MAX_RAY_REFLECTIONS,
+	}
+	for _, setting := range settings {
+		setting(directLighting)
+	}
+	return directLighting
+}
+
+func (d *DirectLighting) Integrate(ray core.Ray, scene *SceneImpl) color.Color {
+	return d.testRay(scene, ray, 0, nil)
+}
+
+func (d *DirectLighting) testRay(scene *SceneImpl, ray core.Ray, reflectionDepth int, interior interior) color.Color {
+	optionalHit := scene.Intersect(ray)
+	if optionalHit.Empty() {
+		return scene.BackgroundColor(ray)
+	}
+
+	if reflectionDepth >= d.maxRayReflections {
+		return color.Black
+	}
+
+	hit := optionalHit.Value()
+	return d.shade(scene, ray, hit, reflectionDepth, interior).MulColor(interior.transmittance(ray, hit))
+}
+
+func (d *DirectLighting) shade(scene *SceneImpl, ray core.Ray, hit geometries.Hit, reflectionDepth int, interior interior) color.Color {
+	reflection := reflect(ray, hit)
+	switch reflection.Type {
+	case materials.Scattered:
+		scatteringPDF := hit.Material.PDF(ray.Direction(), reflection.Ray.Direction(), hit.SurfacePoint)
+		if scatteringPDF == 0 {
+			scatteredInterior := interior.update(ray, hit, reflection)
+			return d.testRay(scene, reflection.Ray, reflectionDepth+1, scatteredInterior).MulColor(reflection.Color)
+		}
+		directLight := scene.sampleDirectLight(ray, hit, d.randomizer)
+		scatteredLight := scene.directEmission(reflection.Ray, scatteringPDF).MulColor(reflection.Color)
+		return directLight.Add(scatteredLight)
+	case materials.Emitted:
+		return reflection.Color
+	case materials.Absorbed:
+		return color.Black
+	default:
+		panic("unknown reflection type")
+	}
+}
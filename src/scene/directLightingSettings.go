@@ -0,0 +1,15 @@
+package scene
+
+import "fmt"
+
+type DirectLightingSetting func(*DirectLighting)
+
+// DirectLightingMaxRayReflections limits how many mirrors and glass surfaces a ray is followed through.
+func DirectLightingMaxRayReflections(maxReflections int) DirectLightingSetting {
+	if maxReflections < 0 {
+		panic(fmt.Errorf("invalid max ray reflections: %d", maxReflections))
+	}
+	return func(directLighting *DirectLighting) {
+		directLighting.maxRayReflections = maxReflections
+	}
+}
@@ -0,0 +1,48 @@
+package scene
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+)
+
+// boundedFog fills the bounding box of the finite scene objects, so that the background stays visible through it.
+type boundedFog struct {
+	medium        geometries.ConstantDensity
+	phaseFunction materials.PhaseFunction
+}
+
+// The fog object over the bounds, there is no fog if the bounds are empty.
+func (f *boundedFog) object(bounds core.Box) (Object, bool) {
+	if bounds.Empty() {
+		return Object{}, false
+	}
+	return Object{Hittable: fogVolume{bounds: bounds, medium: f.medium}, Material: f.phaseFunction}, true
+}
+
+// fogVolume is the medium inside a box, which has no surface itself.
+type fogVolume struct {
+	bounds core.Box
+	medium geometries.ConstantDensity
+}
+
+func (v fogVolume) TestRay(ray core.Ray, params core.Interval) optional.Optional[geometries.Hit] {
+	inside, ok := ray.Clip(v.bounds, params)
+	if !ok {
+		return optional.Empty[geometries.Hit]()
+	}
+	return v.medium.Scatter(ray, []core.Interval{inside})
+}
+
+func (v fogVolume) Transmittance(ray core.Ray, params core.Interval) core.Real {
+	inside, ok := ray.Clip(v.bounds, params)
+	if !ok {
+		return 1
+	}
+	return v.medium.Transmittance(ray, []core.Interval{inside})
+}
+
+func (v fogVolume) BoundingBox() core.Box {
+	return v.bounds
+}
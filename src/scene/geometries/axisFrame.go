@@ -0,0 +1,53 @@
+package geometries
+
+import "github.com/Shamanskiy/go-ray-tracer/src/core"
+
+// axisFrame is an orthonormal frame around the axis of a rotationally symmetric shape.
+// Angles around the axis are measured from x towards z, and x, axis and z form a right-handed frame.
+type axisFrame struct {
+	axis, x, z core.Vec3
+}
+
+func newAxisFrame(axis core.Vec3) axisFrame {
+	axis = axis.Normalize()
+	z, x := core.OrthonormalBasis(axis)
+	return axisFrame{axis: axis, x: x, z: z}
+}
+
+func (f axisFrame) height(v core.Vec3) core.Real {
+	return v.Dot(f.axis)
+}
+
+// The part of the vector orthogonal to the axis.
+func (f axisFrame) radial(v core.Vec3) core.Vec3 {
+	return v.Sub(f.axis.Mul(f.height(v)))
+}
+
+// The angle of the vector around the axis, from 0 to 2 pi.
+func (f axisFrame) angle(v core.Vec3) core.Real {
+	angle := core.Atan2(v.Dot(f.z), v.Dot(f.x))
+	return core.IfElse(angle < 0, angle+2*core.Pi, angle)
+}
+
+// The direction in which the angle grows at the radial vector.
+func (f axisFrame) angular(radial core.Vec3) core.Vec3 {
+	return radial.Cross(f.axis)
+}
+
+// The half-extent of a circle around the axis along the world axes.
+func (f axisFrame) circleExtent(radius core.Real) core.Vec3 {
+	extent := func(component core.Real) core.Real {
+		return radius * core.Sqrt(core.Max(0, 1-component*component))
+	}
+	return core.NewVec3(extent(f.axis.X()), extent(f.axis.Y()), extent(f.axis.Z()))
+}
+
+// The param at which the ray crosses the plane through the point orthogonal to the axis.
+// Rays parallel to the plane don't cross it.
+func (f axisFrame) planeParam(ray core.Ray, point core.Vec3) (core.Real, bool) {
+	denominator := ray.Direction().Dot(f.axis)
+	if core.Abs(denominator) < core.Tolerance {
+		return 0, false
+	}
+	return point.Sub(ray.Origin()).Dot(f.axis) / denominator, true
+}
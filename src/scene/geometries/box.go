@@ -0,0 +1,87 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+// Box is a solid axis-aligned box. Each face spans the unit square in UV space
+// along the next two world axes, e.g. Y and Z for the faces orthogonal to X.
+type Box struct {
+	min, max core.Vec3
+}
+
+func NewBox(min, max core.Vec3) Box {
+	if min.X() > max.X() || min.Y() > max.Y() || min.Z() > max.Z() {
+		panic(fmt.Errorf("new box: min %v must not exceed max %v", min, max))
+	}
+	return Box{min: min, max: max}
+}
+
+// The slab method, which also tracks the faces through which the ray enters and leaves the box.
+// https://www.pbr-book.org/3ed-2018/Shapes/Basic_Shape_Interface#RayndashBoundsIntersections
+func (b Box) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	near, far := -core.Inf(), core.Inf()
+	nearAxis, farAxis := 0, 0
+	for axis := 0; axis < 3; axis++ {
+		invD := 1 / ray.Direction().At(axis)
+		t0 := (b.min.At(axis) - ray.Origin().At(axis)) * invD
+		t1 := (b.max.At(axis) - ray.Origin().At(axis)) * invD
+		if invD < 0 {
+			t0, t1 = t1, t0
+		}
+		if t0 > near {
+			near, nearAxis = t0, axis
+		}
+		if t1 < far {
+			far, farAxis = t1, axis
+		}
+	}
+
+	if near > far {
+		return optional.Empty[Hit]()
+	}
+	if params.Contains(near) {
+		return optional.Of(b.evaluateHit(ray, near, nearAxis))
+	}
+	if params.Contains(far) {
+		return optional.Of(b.evaluateHit(ray, far, farAxis))
+	}
+	return optional.Empty[Hit]()
+}
+
+func (b Box) evaluateHit(ray core.Ray, hitParam core.Real, axis int) Hit {
+	point := ray.Eval(hitParam)
+	uAxis, vAxis := (axis+1)%3, (axis+2)%3
+	onMinFace := point.At(axis)-b.min.At(axis) < b.max.At(axis)-point.At(axis)
+
+	return Hit{
+		Param: hitParam,
+		SurfacePoint: core.SurfacePoint{
+			Point:     point,
+			Normal:    unitAxis(axis).Mul(core.IfElse[core.Real](onMinFace, -1, 1)),
+			UV:        core.NewVec2(b.relative(point, uAxis), b.relative(point, vAxis)),
+			Tangent:   unitAxis(uAxis),
+			Bitangent: unitAxis(vAxis),
+		},
+	}
+}
+
+// The position of the point between the box sides along the axis, from 0 to 1.
+func (b Box) relative(point core.Vec3, axis int) core.Real {
+	size := b.max.At(axis) - b.min.At(axis)
+	if size == 0 {
+		return 0
+	}
+	return (point.At(axis) - b.min.At(axis)) / size
+}
+
+func (b Box) BoundingBox() core.Box {
+	return core.NewBox(b.min, b.max)
+}
+
+func unitAxis(axis int) core.Vec3 {
+	return withComponent(core.NewVec3(0, 0, 0), axis, 1)
+}
@@ -14,7 +14,25 @@ type BVHNode struct {
 	rightChild  Hittable
 }
 
-func BuildBVH(hittables []Hittable) *BVHNode {
+func BuildBVH(hittables []Hittable, settings ...BVHSetting) *BVHNode {
+	bvhSettings := defaultBVHSettings()
+	for _, setting := range settings {
+		setting(&bvhSettings)
+	}
+
+	switch bvhSettings.splitMethod {
+	case SplitMedian:
+		return buildMedianBVH(hittables)
+	case SplitSAH:
+		return buildSAHBVH(hittables, bvhSettings.maxLeafSize)
+	default:
+		panic("unknown BVH split method")
+	}
+}
+
+// Sorts the hittables along a random axis and splits them in halves.
+// Fast to build, but produces poor trees for unevenly distributed geometries.
+func buildMedianBVH(hittables []Hittable) *BVHNode {
 	sortingAxis := rand.Intn(3)
 	sort.Slice(hittables, func(i, j int) bool {
 		return compareGeometries(hittables, i, j, sortingAxis)
@@ -30,8 +48,8 @@ func BuildBVH(hittables []Hittable) *BVHNode {
 		bvhNode.leftChild = hittables[0]
 		bvhNode.rightChild = emptyHittable{}
 	default:
-		bvhNode.leftChild = BuildBVH(hittables[0 : len(hittables)/2])
-		bvhNode.rightChild = BuildBVH(hittables[len(hittables)/2:])
+		bvhNode.leftChild = buildMedianBVH(hittables[0 : len(hittables)/2])
+		bvhNode.rightChild = buildMedianBVH(hittables[len(hittables)/2:])
 	}
 
 	bvhNode.boundingBox = bvhNode.leftChild.BoundingBox().Union(bvhNode.rightChild.BoundingBox())
@@ -45,6 +63,24 @@ func compareGeometries(hittables []Hittable, i, j, sortingAxis int) bool {
 	return minI < minj
 }
 
+func newLeafNode(hittables []Hittable) *BVHNode {
+	switch len(hittables) {
+	case 0:
+		return &BVHNode{boundingBox: core.NewEmptyBox(), leftChild: emptyHittable{}, rightChild: emptyHittable{}}
+	case 1:
+		return &BVHNode{boundingBox: hittables[0].BoundingBox(), leftChild: hittables[0], rightChild: emptyHittable{}}
+	default:
+		list := newHittableList(hittables)
+		return &BVHNode{boundingBox: list.BoundingBox(), leftChild: list, rightChild: emptyHittable{}}
+	}
+}
+
+// Leaves keep their hittables in the left child, the right child is empty.
+func (bvh *BVHNode) isLeaf() bool {
+	_, rightEmpty := bvh.rightChild.(emptyHittable)
+	return rightEmpty
+}
+
 func (bhv *BVHNode) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
 	if !ray.Hits(bhv.boundingBox, params) {
 		return optional.Empty[Hit]()
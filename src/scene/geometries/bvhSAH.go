@@ -0,0 +1,176 @@
+package geometries
+
+import "github.com/Shamanskiy/go-ray-tracer/src/core"
+
+const (
+	sahBinCount         = 16
+	sahTraversalCost    = 1.
+	sahIntersectionCost = 1.
+)
+
+type sahPrimitive struct {
+	hittable    Hittable
+	boundingBox core.Box
+	centroid    core.Vec3
+}
+
+// Surface Area Heuristic: the probability of a ray hitting a child node is proportional
+// to its surface area, so we pick the split that minimizes the expected traversal cost.
+// Split candidates are evaluated on bins along each axis instead of every primitive.
+// https://www.pbr-book.org/3ed-2018/Primitives_and_Intersection_Acceleration/Bounding_Volume_Hierarchies
+func buildSAHBVH(hittables []Hittable, maxLeafSize int) *BVHNode {
+	primitives := make([]sahPrimitive, 0, len(hittables))
+	for _, hittable := range hittables {
+		boundingBox := hittable.BoundingBox()
+		primitives = append(primitives, sahPrimitive{hittable, boundingBox, centroid(boundingBox)})
+	}
+
+	return buildSAHNode(primitives, maxLeafSize)
+}
+
+func buildSAHNode(primitives []sahPrimitive, maxLeafSize int) *BVHNode {
+	if len(primitives) <= 1 {
+		return newSAHLeaf(primitives)
+	}
+
+	boundingBox := core.NewEmptyBox()
+	centroidBox := core.NewEmptyBox()
+	for _, primitive := range primitives {
+		boundingBox = boundingBox.Union(primitive.boundingBox)
+		centroidBox = centroidBox.ExtendTo(primitive.centroid)
+	}
+
+	split, found := findSAHSplit(primitives, boundingBox, centroidBox)
+	leafCost := core.Real(sahIntersectionCost * len(primitives))
+	if len(primitives) <= maxLeafSize && (!found || split.cost >= leafCost) {
+		return newSAHLeaf(primitives)
+	}
+
+	middle := len(primitives) / 2
+	if found {
+		middle = partition(primitives, func(primitive sahPrimitive) bool {
+			return binIndex(primitive.centroid, centroidBox, split.axis) <= split.bin
+		})
+	}
+	if middle == 0 || middle == len(primitives) {
+		// All centroids fall into the same bin, any split is as good as another
+		middle = len(primitives) / 2
+	}
+
+	return &BVHNode{
+		boundingBox: boundingBox,
+		leftChild:   buildSAHNode(primitives[:middle], maxLeafSize),
+		rightChild:  buildSAHNode(primitives[middle:], maxLeafSize),
+	}
+}
+
+func newSAHLeaf(primitives []sahPrimitive) *BVHNode {
+	hittables := make([]Hittable, 0, len(primitives))
+	for _, primitive := range primitives {
+		hittables = append(hittables, primitive.hittable)
+	}
+	return newLeafNode(hittables)
+}
+
+type sahSplit struct {
+	axis int
+	bin  int // primitives in bins up to this one go to the left child
+	cost core.Real
+}
+
+type sahBin struct {
+	boundingBox core.Box
+	count       int
+}
+
+func findSAHSplit(primitives []sahPrimitive, boundingBox, centroidBox core.Box) (sahSplit, bool) {
+	bestSplit := sahSplit{cost: core.Inf()}
+	found := false
+
+	for axis := 0; axis < 3; axis++ {
+		if centroidBox.Max().At(axis) <= centroidBox.Min().At(axis) {
+			continue
+		}
+
+		var bins [sahBinCount]sahBin
+		for i := range bins {
+			bins[i].boundingBox = core.NewEmptyBox()
+		}
+		for _, primitive := range primitives {
+			bin := &bins[binIndex(primitive.centroid, centroidBox, axis)]
+			bin.boundingBox = bin.boundingBox.Union(primitive.boundingBox)
+			bin.count++
+		}
+
+		// Sweep from the right to accumulate the right side areas,
+		// then from the left to evaluate the split costs
+		var rightAreas [sahBinCount]core.Real
+		var rightCounts [sahBinCount]int
+		rightBox := core.NewEmptyBox()
+		rightCount := 0
+		for bin := sahBinCount - 1; bin > 0; bin-- {
+			rightBox = rightBox.Union(bins[bin].boundingBox)
+			rightCount += bins[bin].count
+			rightAreas[bin] = rightBox.SurfaceArea()
+			rightCounts[bin] = rightCount
+		}
+
+		leftBox := core.NewEmptyBox()
+		leftCount := 0
+		for bin := 0; bin < sahBinCount-1; bin++ {
+			leftBox = leftBox.Union(bins[bin].boundingBox)
+			leftCount += bins[bin].count
+			if leftCount == 0 || rightCounts[bin+1] == 0 {
+				continue
+			}
+
+			cost := sahCost(leftBox.SurfaceArea(), leftCount, rightAreas[bin+1], rightCounts[bin+1], boundingBox.SurfaceArea())
+			if cost < bestSplit.cost {
+				bestSplit = sahSplit{axis: axis, bin: bin, cost: cost}
+				found = true
+			}
+		}
+	}
+
+	return bestSplit, found
+}
+
+func sahCost(leftArea core.Real, leftCount int, rightArea core.Real, rightCount int, parentArea core.Real) core.Real {
+	if parentArea <= 0 {
+		return sahTraversalCost + sahIntersectionCost*core.Real(leftCount+rightCount)
+	}
+	weightedCount := leftArea*core.Real(leftCount) + rightArea*core.Real(rightCount)
+	return sahTraversalCost + sahIntersectionCost*weightedCount/parentArea
+}
+
+func binIndex(point core.Vec3, centroidBox core.Box, axis int) int {
+	low := centroidBox.Min().At(axis)
+	high := centroidBox.Max().At(axis)
+	bin := int(sahBinCount * (point.At(axis) - low) / (high - low))
+	return core.IfElse(bin < sahBinCount, bin, sahBinCount-1)
+}
+
+// Unbounded boxes, e.g. of infinite planes, are centered at zero along their infinite axes.
+func centroid(boundingBox core.Box) core.Vec3 {
+	center := boundingBox.Center()
+	return core.NewVec3(finiteOrZero(center.X()), finiteOrZero(center.Y()), finiteOrZero(center.Z()))
+}
+
+func finiteOrZero(value core.Real) core.Real {
+	if core.IsNaN(value) || core.IsInf(value, 0) {
+		return 0
+	}
+	return value
+}
+
+// Moves the elements satisfying the predicate to the front, returns their number.
+func partition[T any](slice []T, predicate func(T) bool) int {
+	front := 0
+	for i := range slice {
+		if predicate(slice[i]) {
+			slice[front], slice[i] = slice[i], slice[front]
+			front++
+		}
+	}
+	return front
+}
@@ -0,0 +1,46 @@
+package geometries
+
+import "fmt"
+
+type SplitMethod int
+
+const (
+	SplitMedian SplitMethod = iota
+	SplitSAH
+)
+
+const DEFAULT_BVH_MAX_LEAF_SIZE = 4
+
+type bvhSettings struct {
+	splitMethod SplitMethod
+	maxLeafSize int
+}
+
+func defaultBVHSettings() bvhSettings {
+	return bvhSettings{
+		splitMethod: SplitSAH,
+		maxLeafSize: DEFAULT_BVH_MAX_LEAF_SIZE,
+	}
+}
+
+type BVHSetting func(*bvhSettings)
+
+func BVHSplitMethod(method SplitMethod) BVHSetting {
+	if method != SplitMedian && method != SplitSAH {
+		panic(fmt.Errorf("invalid BVH split method: %d", method))
+	}
+	return func(settings *bvhSettings) {
+		settings.splitMethod = method
+	}
+}
+
+// BVHMaxLeafSize limits the number of hittables the SAH builder can put in a leaf.
+// The median builder always produces leaves with one hittable.
+func BVHMaxLeafSize(size int) BVHSetting {
+	if size < 1 {
+		panic(fmt.Errorf("invalid BVH max leaf size: %d", size))
+	}
+	return func(settings *bvhSettings) {
+		settings.maxLeafSize = size
+	}
+}
@@ -0,0 +1,64 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+)
+
+// BVHStats describes the quality of a BVH. The SAH cost is the expected cost of tracing
+// a random ray through the tree, lower is better.
+type BVHStats struct {
+	Depth        int
+	NodeCount    int
+	LeafCount    int
+	MaxLeafSize  int
+	MeanLeafSize core.Real
+	SAHCost      core.Real
+}
+
+func (s BVHStats) String() string {
+	return fmt.Sprintf("depth %d, nodes %d, leaves %d, max leaf size %d, mean leaf size %.2f, SAH cost %.2f",
+		s.Depth, s.NodeCount, s.LeafCount, s.MaxLeafSize, s.MeanLeafSize, s.SAHCost)
+}
+
+func (bvh *BVHNode) Stats() BVHStats {
+	stats := BVHStats{}
+	primitiveCount := 0
+	rootArea := bvh.boundingBox.SurfaceArea()
+
+	var visit func(node *BVHNode, depth int)
+	visit = func(node *BVHNode, depth int) {
+		stats.NodeCount++
+		stats.Depth = core.IfElse(depth > stats.Depth, depth, stats.Depth)
+		relativeArea := core.IfElse(rootArea > 0, node.boundingBox.SurfaceArea()/rootArea, 1)
+
+		if !node.isLeaf() {
+			stats.SAHCost += sahTraversalCost * relativeArea
+			visit(node.leftChild.(*BVHNode), depth+1)
+			visit(node.rightChild.(*BVHNode), depth+1)
+			return
+		}
+
+		leafSize := leafSize(node)
+		stats.LeafCount++
+		stats.MaxLeafSize = core.IfElse(leafSize > stats.MaxLeafSize, leafSize, stats.MaxLeafSize)
+		stats.SAHCost += sahIntersectionCost * core.Real(leafSize) * relativeArea
+		primitiveCount += leafSize
+	}
+	visit(bvh, 1)
+
+	stats.MeanLeafSize = core.Real(primitiveCount) / core.Real(stats.LeafCount)
+	return stats
+}
+
+func leafSize(leaf *BVHNode) int {
+	switch child := leaf.leftChild.(type) {
+	case emptyHittable:
+		return 0
+	case hittableList:
+		return len(child.hittables)
+	default:
+		return 1
+	}
+}
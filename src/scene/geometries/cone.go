@@ -0,0 +1,107 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+// Cone is a solid cone from the center of its base to the apex, closed with a disk.
+// The side maps the angle around the axis to U and the height to V.
+type Cone struct {
+	hittableList
+}
+
+func NewCone(base, apex core.Vec3, radius core.Real) Cone {
+	axis := apex.Sub(base)
+	if axis.LenSqr() == 0 {
+		panic(fmt.Errorf("new cone: base and apex coincide: %v", base))
+	}
+	if radius <= 0 {
+		panic(fmt.Errorf("new cone: radius must be positive, got %v", radius))
+	}
+
+	side := coneSide{base: base, height: axis.Len(), frame: newAxisFrame(axis), radius: radius}
+	return Cone{newHittableList([]Hittable{side, NewDisk(base, axis.Mul(-1), radius)})}
+}
+
+type coneSide struct {
+	base   core.Vec3
+	height core.Real
+	frame  axisFrame
+	radius core.Real
+}
+
+// The radius at a height h is k * (height - h) for the slope k = radius / height, so points on
+// the side satisfy |radial|^2 = k^2 * (height - h)^2, which is quadratic along the ray.
+func (s coneSide) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	origin := ray.Origin().Sub(s.base)
+	radialOrigin, radialDirection := s.frame.radial(origin), s.frame.radial(ray.Direction())
+	heightToApex, heightStep := s.height-s.frame.height(origin), s.frame.height(ray.Direction())
+	slopeSquared := s.slope() * s.slope()
+
+	a := radialDirection.LenSqr() - slopeSquared*heightStep*heightStep
+	b := 2 * (radialDirection.Dot(radialOrigin) + slopeSquared*heightToApex*heightStep)
+	c := radialOrigin.LenSqr() - slopeSquared*heightToApex*heightToApex
+
+	for _, hitParam := range solveQuadOrLinear(a, b, c) {
+		if !params.Contains(hitParam) {
+			continue
+		}
+		point := ray.Eval(hitParam)
+		// The equation also describes the mirrored cone above the apex
+		height := s.frame.height(point.Sub(s.base))
+		if height >= 0 && height <= s.height {
+			return optional.Of(Hit{Param: hitParam, SurfacePoint: s.surfacePoint(point, height)})
+		}
+	}
+	return optional.Empty[Hit]()
+}
+
+func (s coneSide) surfacePoint(point core.Vec3, height core.Real) core.SurfacePoint {
+	radial := s.frame.radial(point.Sub(s.base))
+	radialLength := radial.Len()
+	if radialLength == 0 {
+		tangent, bitangent := core.OrthonormalBasis(s.frame.axis)
+		return core.SurfacePoint{Point: point, Normal: s.frame.axis, UV: core.NewVec2(0, 1), Tangent: tangent, Bitangent: bitangent}
+	}
+
+	outward := radial.Div(radialLength)
+	normal := outward.Add(s.frame.axis.Mul(s.slope())).Normalize()
+	towardsApex := s.frame.axis.Sub(outward.Mul(s.slope()))
+	tangent, bitangent := core.TangentFrame(normal, s.frame.angular(radial), towardsApex)
+	return core.SurfacePoint{
+		Point:     point,
+		Normal:    normal,
+		UV:        core.NewVec2(s.frame.angle(radial)/(2*core.Pi), height/s.height),
+		Tangent:   tangent,
+		Bitangent: bitangent,
+	}
+}
+
+func (s coneSide) slope() core.Real {
+	return s.radius / s.height
+}
+
+func (s coneSide) BoundingBox() core.Box {
+	extent := s.frame.circleExtent(s.radius)
+	apex := s.base.Add(s.frame.axis.Mul(s.height))
+	return core.NewBox(s.base.Sub(extent), s.base.Add(extent)).ExtendTo(apex)
+}
+
+// Roots in ascending order, the equation degenerates to a linear one for rays parallel to the cone side.
+func solveQuadOrLinear(a, b, c core.Real) []core.Real {
+	if core.Abs(a) < core.Tolerance*(core.Abs(b)+core.Abs(c)) {
+		if b == 0 {
+			return nil
+		}
+		return []core.Real{-c / b}
+	}
+
+	solution := core.SolveQuadEquation(a, b, c)
+	if solution.NoSolution {
+		return nil
+	}
+	return []core.Real{core.Min(solution.Left, solution.Right), core.Max(solution.Left, solution.Right)}
+}
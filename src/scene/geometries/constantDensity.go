@@ -0,0 +1,47 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+// ConstantDensity is a participating medium of constant density filling some parts of a ray.
+// Rays are scattered at random points with exponentially distributed free-flight distances,
+// denser media scatter them sooner. It's shared by the media with different boundaries.
+type ConstantDensity struct {
+	density    core.Real
+	randomizer random.RandomGenerator
+}
+
+func NewConstantDensity(density core.Real, randomizer random.RandomGenerator) ConstantDensity {
+	if density <= 0 {
+		panic(fmt.Errorf("density must be positive, got %f", density))
+	}
+	return ConstantDensity{density: density, randomizer: randomizer}
+}
+
+// Scatter samples where the ray scatters in the medium filling the intervals of ray params,
+// which must be disjoint and ordered along the ray. The free flight goes on through the gaps.
+func (d ConstantDensity) Scatter(ray core.Ray, inside []core.Interval) optional.Optional[Hit] {
+	freeFlight := sampleFreeFlight(d.density, d.randomizer) / ray.Direction().Len()
+	for _, interval := range inside {
+		length := interval.Max() - interval.Min()
+		if freeFlight < length {
+			return optional.Of(mediumHit(ray, interval.Min()+freeFlight))
+		}
+		freeFlight -= length
+	}
+	return optional.Empty[Hit]()
+}
+
+// Beer-Lambert law over the intervals of ray params filled with the medium.
+func (d ConstantDensity) Transmittance(ray core.Ray, inside []core.Interval) core.Real {
+	thickness := core.Real(0)
+	for _, interval := range inside {
+		thickness += interval.Max() - interval.Min()
+	}
+	return core.Exp(-d.density * thickness * ray.Direction().Len())
+}
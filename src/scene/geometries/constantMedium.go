@@ -0,0 +1,48 @@
+package geometries
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+// ConstantMedium fills a closed boundary with a participating medium of constant density,
+// like fog or smoke. Rays passing through it are hit at random points, denser media are hit sooner.
+// The object material should be a phase function, like materials.HenyeyGreenstein,
+// which defines the albedo of the medium.
+// https://raytracing.github.io/books/RayTracingTheNextWeek.html#volumes
+type ConstantMedium struct {
+	boundary Hittable
+	medium   ConstantDensity
+}
+
+func NewConstantMedium(boundary Hittable, density core.Real, randomizer random.RandomGenerator) ConstantMedium {
+	return ConstantMedium{boundary: boundary, medium: NewConstantDensity(density, randomizer)}
+}
+
+// Rays may pass through several parts of a non-convex boundary, the free flight goes on from one to the next.
+func (m ConstantMedium) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	return m.medium.Scatter(ray, m.inside(ray, params))
+}
+
+func (m ConstantMedium) Transmittance(ray core.Ray, params core.Interval) core.Real {
+	return m.medium.Transmittance(ray, m.inside(ray, params))
+}
+
+func (m ConstantMedium) BoundingBox() core.Box {
+	return m.boundary.BoundingBox()
+}
+
+// The parts of the ray within the params that are inside the boundary.
+// Rays starting inside the medium enter it behind their origin.
+func (m ConstantMedium) inside(ray core.Ray, params core.Interval) []core.Interval {
+	intervals := []core.Interval{}
+	for _, span := range Spans(m.boundary, ray) {
+		start := core.Max(span.Entry.Param, params.Min())
+		end := core.Min(span.Exit.Param, params.Max())
+		if start < end {
+			intervals = append(intervals, core.NewInterval(start, end))
+		}
+	}
+	return intervals
+}
@@ -0,0 +1,129 @@
+package geometries
+
+import (
+	"sort"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+type CSGOperation int
+
+const (
+	CSGUnion CSGOperation = iota
+	CSGIntersection
+	CSGDifference
+)
+
+// CSG combines two closed hittables with a boolean operation, like a lens as the intersection
+// of two spheres or a drilled box as the difference of a box and a cylinder. CSGs are solids,
+// so they can be combined further.
+// https://en.wikipedia.org/wiki/Constructive_solid_geometry
+type CSG struct {
+	first, second Hittable
+	operation     CSGOperation
+	boundingBox   core.Box
+}
+
+func NewUnion(first, second Hittable) CSG {
+	return CSG{first: first, second: second, operation: CSGUnion,
+		boundingBox: first.BoundingBox().Union(second.BoundingBox())}
+}
+
+func NewIntersection(first, second Hittable) CSG {
+	return CSG{first: first, second: second, operation: CSGIntersection,
+		boundingBox: intersectBoxes(first.BoundingBox(), second.BoundingBox())}
+}
+
+// NewDifference cuts the second hittable out of the first one.
+func NewDifference(first, second Hittable) CSG {
+	return CSG{first: first, second: second, operation: CSGDifference, boundingBox: first.BoundingBox()}
+}
+
+func (c CSG) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	if !ray.Hits(c.boundingBox, params) {
+		return optional.Empty[Hit]()
+	}
+
+	for _, span := range c.Spans(ray) {
+		if params.Contains(span.Entry.Param) {
+			return optional.Of(span.Entry)
+		}
+		if params.Contains(span.Exit.Param) {
+			return optional.Of(span.Exit)
+		}
+	}
+	return optional.Empty[Hit]()
+}
+
+func (c CSG) BoundingBox() core.Box {
+	return c.boundingBox
+}
+
+type csgCrossing struct {
+	hit      Hit
+	entering bool
+	first    bool
+}
+
+// Walks the boundary crossings of both hittables along the ray and keeps those
+// where the ray enters or leaves the combination.
+func (c CSG) Spans(ray core.Ray) []Span {
+	crossings := []csgCrossing{}
+	for _, span := range Spans(c.first, ray) {
+		crossings = append(crossings, csgCrossing{span.Entry, true, true}, csgCrossing{span.Exit, false, true})
+	}
+	for _, span := range Spans(c.second, ray) {
+		crossings = append(crossings, csgCrossing{span.Entry, true, false}, csgCrossing{span.Exit, false, false})
+	}
+	sort.SliceStable(crossings, func(i, j int) bool {
+		return crossings[i].hit.Param < crossings[j].hit.Param
+	})
+
+	spans := []Span{}
+	var entry Hit
+	inFirst, inSecond, inside := false, false, false
+	for _, crossing := range crossings {
+		if crossing.first {
+			inFirst = crossing.entering
+		} else {
+			inSecond = crossing.entering
+		}
+		if c.contains(inFirst, inSecond) == inside {
+			continue
+		}
+
+		hit := crossing.hit
+		if c.operation == CSGDifference && !crossing.first {
+			// The surface of the subtracted hittable faces into the remaining solid
+			hit.Normal = hit.Normal.Mul(-1)
+			hit.Bitangent = hit.Bitangent.Mul(-1)
+		}
+		if inside {
+			spans = append(spans, Span{Entry: entry, Exit: hit})
+		} else {
+			entry = hit
+		}
+		inside = !inside
+	}
+	return spans
+}
+
+func (c CSG) contains(inFirst, inSecond bool) bool {
+	switch c.operation {
+	case CSGUnion:
+		return inFirst || inSecond
+	case CSGIntersection:
+		return inFirst && inSecond
+	default:
+		return inFirst && !inSecond
+	}
+}
+
+func intersectBoxes(a, b core.Box) core.Box {
+	box := core.NewBox(core.Vec3Max(a.Min(), b.Min()), core.Vec3Min(a.Max(), b.Max()))
+	if box.Empty() {
+		return core.NewEmptyBox()
+	}
+	return box
+}
@@ -0,0 +1,86 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+// Cylinder is a solid cylinder between the centers of its base and top, closed with disks.
+// The side maps the angle around the axis to U and the height to V.
+type Cylinder struct {
+	hittableList
+}
+
+func NewCylinder(base, top core.Vec3, radius core.Real) Cylinder {
+	axis := top.Sub(base)
+	if axis.LenSqr() == 0 {
+		panic(fmt.Errorf("new cylinder: base and top coincide: %v", base))
+	}
+	if radius <= 0 {
+		panic(fmt.Errorf("new cylinder: radius must be positive, got %v", radius))
+	}
+
+	side := cylinderSide{base: base, height: axis.Len(), frame: newAxisFrame(axis), radius: radius}
+	return Cylinder{newHittableList([]Hittable{
+		side,
+		NewDisk(base, axis.Mul(-1), radius),
+		NewDisk(top, axis, radius),
+	})}
+}
+
+type cylinderSide struct {
+	base   core.Vec3
+	height core.Real
+	frame  axisFrame
+	radius core.Real
+}
+
+func (s cylinderSide) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	radialOrigin := s.frame.radial(ray.Origin().Sub(s.base))
+	radialDirection := s.frame.radial(ray.Direction())
+
+	a := radialDirection.LenSqr()
+	if a == 0 {
+		return optional.Empty[Hit]()
+	}
+	b := 2 * radialDirection.Dot(radialOrigin)
+	c := radialOrigin.LenSqr() - s.radius*s.radius
+	solution := core.SolveQuadEquation(a, b, c)
+	if solution.NoSolution {
+		return optional.Empty[Hit]()
+	}
+
+	for _, hitParam := range []core.Real{solution.Left, solution.Right} {
+		if !params.Contains(hitParam) {
+			continue
+		}
+		point := ray.Eval(hitParam)
+		height := s.frame.height(point.Sub(s.base))
+		if height >= 0 && height <= s.height {
+			return optional.Of(Hit{Param: hitParam, SurfacePoint: s.surfacePoint(point, height)})
+		}
+	}
+	return optional.Empty[Hit]()
+}
+
+func (s cylinderSide) surfacePoint(point core.Vec3, height core.Real) core.SurfacePoint {
+	radial := s.frame.radial(point.Sub(s.base))
+	normal := radial.Div(s.radius)
+	tangent, bitangent := core.TangentFrame(normal, s.frame.angular(radial), s.frame.axis)
+	return core.SurfacePoint{
+		Point:     point,
+		Normal:    normal,
+		UV:        core.NewVec2(s.frame.angle(radial)/(2*core.Pi), height/s.height),
+		Tangent:   tangent,
+		Bitangent: bitangent,
+	}
+}
+
+func (s cylinderSide) BoundingBox() core.Box {
+	extent := s.frame.circleExtent(s.radius)
+	top := s.base.Add(s.frame.axis.Mul(s.height))
+	return core.NewBox(s.base.Sub(extent), s.base.Add(extent)).
+		Union(core.NewBox(top.Sub(extent), top.Add(extent)))
+}
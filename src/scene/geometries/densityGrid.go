@@ -0,0 +1,88 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+)
+
+// DensityGrid holds densities of a heterogeneous medium sampled at the voxel centers of a box.
+// Densities are stored with X changing fastest, then Y, then Z. Between the voxel centers they are
+// interpolated trilinearly, outside of the box the density is zero.
+type DensityGrid struct {
+	resolution [3]int
+	bounds     core.Box
+	densities  []core.Real
+	maxDensity core.Real
+}
+
+func NewDensityGrid(resolution [3]int, bounds core.Box, densities []core.Real) *DensityGrid {
+	if resolution[0] < 1 || resolution[1] < 1 || resolution[2] < 1 {
+		panic(fmt.Errorf("invalid density grid resolution: %v", resolution))
+	}
+	if len(densities) != resolution[0]*resolution[1]*resolution[2] {
+		panic(fmt.Errorf("density grid of resolution %v needs %d densities, got %d",
+			resolution, resolution[0]*resolution[1]*resolution[2], len(densities)))
+	}
+	for axis := 0; axis < 3; axis++ {
+		if !(bounds.Min().At(axis) < bounds.Max().At(axis)) {
+			panic(fmt.Errorf("density grid bounds must have positive size, got %v", bounds))
+		}
+	}
+
+	maxDensity := core.Real(0)
+	for _, density := range densities {
+		if density < 0 {
+			panic(fmt.Errorf("density grid has negative density %f", density))
+		}
+		maxDensity = core.Max(maxDensity, density)
+	}
+	return &DensityGrid{resolution: resolution, bounds: bounds, densities: densities, maxDensity: maxDensity}
+}
+
+func (g *DensityGrid) Bounds() core.Box {
+	return g.bounds
+}
+
+// MaxDensity bounds the density everywhere in the grid.
+func (g *DensityGrid) MaxDensity() core.Real {
+	return g.maxDensity
+}
+
+func (g *DensityGrid) DensityAt(point core.Vec3) core.Real {
+	var lower, upper [3]int
+	var fraction [3]core.Real
+	for axis := 0; axis < 3; axis++ {
+		min, max := g.bounds.Min().At(axis), g.bounds.Max().At(axis)
+		if point.At(axis) < min || point.At(axis) > max {
+			return 0
+		}
+		last := g.resolution[axis] - 1
+		coordinate := (point.At(axis)-min)/(max-min)*core.Real(g.resolution[axis]) - 0.5
+		coordinate = core.Max(0, core.Min(core.Real(last), coordinate))
+		lower[axis] = int(core.Floor(coordinate))
+		upper[axis] = lower[axis] + core.IfElse(lower[axis] < last, 1, 0)
+		fraction[axis] = coordinate - core.Real(lower[axis])
+	}
+
+	density := core.Real(0)
+	for corner := 0; corner < 8; corner++ {
+		weight := core.Real(1)
+		var index [3]int
+		for axis := 0; axis < 3; axis++ {
+			if corner&(1<<axis) != 0 {
+				index[axis] = upper[axis]
+				weight *= fraction[axis]
+			} else {
+				index[axis] = lower[axis]
+				weight *= 1 - fraction[axis]
+			}
+		}
+		density += weight * g.voxel(index)
+	}
+	return density
+}
+
+func (g *DensityGrid) voxel(index [3]int) core.Real {
+	return g.densities[index[0]+g.resolution[0]*(index[1]+g.resolution[1]*index[2])]
+}
@@ -0,0 +1,74 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+// Disk is a flat circle facing the normal. Its UVs span the unit square around it,
+// so that square textures are mapped on it like a decal.
+type Disk struct {
+	center core.Vec3
+	frame  axisFrame
+	radius core.Real
+}
+
+func NewDisk(center, normal core.Vec3, radius core.Real) Disk {
+	if normal.LenSqr() == 0 {
+		panic(fmt.Errorf("new disk: normal must not be zero"))
+	}
+	if radius <= 0 {
+		panic(fmt.Errorf("new disk: radius must be positive, got %v", radius))
+	}
+	return Disk{center: center, frame: newAxisFrame(normal), radius: radius}
+}
+
+func (d Disk) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	hitParam, crosses := d.frame.planeParam(ray, d.center)
+	if !crosses || !params.Contains(hitParam) {
+		return optional.Empty[Hit]()
+	}
+
+	point := ray.Eval(hitParam)
+	if point.Sub(d.center).LenSqr() > d.radius*d.radius {
+		return optional.Empty[Hit]()
+	}
+	return optional.Of(Hit{Param: hitParam, SurfacePoint: d.surfacePoint(point)})
+}
+
+func (d Disk) surfacePoint(point core.Vec3) core.SurfacePoint {
+	offset := point.Sub(d.center).Div(2 * d.radius)
+	return core.SurfacePoint{
+		Point:     point,
+		Normal:    d.frame.axis,
+		UV:        core.NewVec2(0.5+offset.Dot(d.frame.x), 0.5+offset.Dot(d.frame.z)),
+		Tangent:   d.frame.x,
+		Bitangent: d.frame.z,
+	}
+}
+
+func (d Disk) BoundingBox() core.Box {
+	extent := d.frame.circleExtent(d.radius)
+	return core.NewBox(d.center.Sub(extent), d.center.Add(extent))
+}
+
+// Sample picks a point uniformly over the disk area.
+func (d Disk) Sample(origin core.Vec3, randomizer random.RandomGenerator) SurfaceSample {
+	offset := randomizer.Vec3InUnitDisk().Mul(d.radius)
+	point := d.center.Add(d.frame.x.Mul(offset.X())).Add(d.frame.z.Mul(offset.Y()))
+	return SurfaceSample{
+		SurfacePoint: d.surfacePoint(point),
+		PDF:          areaToSolidAnglePDF(1/d.area(), origin, point, d.frame.axis),
+	}
+}
+
+func (d Disk) PDF(origin core.Vec3, hit Hit) core.Real {
+	return areaToSolidAnglePDF(1/d.area(), origin, hit.Point, d.frame.axis)
+}
+
+func (d Disk) area() core.Real {
+	return core.Pi * d.radius * d.radius
+}
@@ -0,0 +1,81 @@
+package geometries
+
+import "github.com/Shamanskiy/go-ray-tracer/src/core"
+
+// DistanceFunction returns the signed distance from the point to a surface, negative inside it.
+// The methods combine and deform distance functions, see https://iquilezles.org/articles/distfunctions/
+type DistanceFunction func(point core.Vec3) core.Real
+
+func SphereDistance(center core.Vec3, radius core.Real) DistanceFunction {
+	return func(point core.Vec3) core.Real {
+		return point.Sub(center).Len() - radius
+	}
+}
+
+func BoxDistance(center, halfSize core.Vec3) DistanceFunction {
+	return func(point core.Vec3) core.Real {
+		offset := point.Sub(center)
+		q := core.NewVec3(core.Abs(offset.X()), core.Abs(offset.Y()), core.Abs(offset.Z())).Sub(halfSize)
+		outside := core.Vec3Max(q, core.NewVec3(0, 0, 0)).Len()
+		inside := core.Min(core.Max(q.X(), core.Max(q.Y(), q.Z())), 0)
+		return outside + inside
+	}
+}
+
+// TorusDistance is a torus around the Y axis.
+func TorusDistance(center core.Vec3, majorRadius, minorRadius core.Real) DistanceFunction {
+	return func(point core.Vec3) core.Real {
+		offset := point.Sub(center)
+		ring := core.Hypot(offset.X(), offset.Z()) - majorRadius
+		return core.Hypot(ring, offset.Y()) - minorRadius
+	}
+}
+
+// SmoothUnion blends the shapes together within the smoothness distance of both.
+func (f DistanceFunction) SmoothUnion(other DistanceFunction, smoothness core.Real) DistanceFunction {
+	return func(point core.Vec3) core.Real {
+		a, b := f(point), other(point)
+		if smoothness <= 0 {
+			return core.Min(a, b)
+		}
+		h := core.Max(0, core.Min(1, 0.5+0.5*(b-a)/smoothness))
+		return b + (a-b)*h - smoothness*h*(1-h)
+	}
+}
+
+// Round inflates the shape by the radius, rounding its edges.
+func (f DistanceFunction) Round(radius core.Real) DistanceFunction {
+	return func(point core.Vec3) core.Real {
+		return f(point) - radius
+	}
+}
+
+// Repeat copies the shape around the origin infinitely with the period along each axis,
+// axes with zero period aren't repeated. The distance is exact if the shape fits into its cell.
+func (f DistanceFunction) Repeat(period core.Vec3) DistanceFunction {
+	wrap := func(value, period core.Real) core.Real {
+		if period == 0 {
+			return value
+		}
+		return value - period*core.Round(value/period)
+	}
+	return func(point core.Vec3) core.Real {
+		return f(core.NewVec3(wrap(point.X(), period.X()), wrap(point.Y(), period.Y()), wrap(point.Z(), period.Z())))
+	}
+}
+
+// Twist rotates the shape around the Y axis by the rate in degrees per unit of height.
+// Twisting overestimates distances, so twisted shapes need a smaller SDFStepScale.
+func (f DistanceFunction) Twist(rate core.Real) DistanceFunction {
+	rateInRadians := rate * core.Pi / 180
+	return func(point core.Vec3) core.Real {
+		sin, cos := core.Sincos(-rateInRadians * point.Y())
+		return f(core.NewVec3(cos*point.X()-sin*point.Z(), point.Y(), sin*point.X()+cos*point.Z()))
+	}
+}
+
+func (f DistanceFunction) Translate(offset core.Vec3) DistanceFunction {
+	return func(point core.Vec3) core.Real {
+		return f(point.Sub(offset))
+	}
+}
@@ -0,0 +1,179 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+const heightfieldClipPadding = 1e-4
+
+// Heightfield is a terrain surface over the XZ rectangle of its bounds. Heights from 0 to 1 are
+// sampled at the grid vertices with X changing fastest, then Z, and span the Y range of the bounds.
+// Each grid cell is split into two triangles, which are only built for the cells that a ray crosses,
+// so large terrains take one Real per vertex. Normals are smooth and UVs span the whole terrain.
+type Heightfield struct {
+	resolution  [2]int
+	bounds      core.Box
+	heights     []core.Real
+	cellSize    [2]core.Real
+	boundingBox core.Box
+}
+
+func NewHeightfield(resolution [2]int, bounds core.Box, heights []core.Real) *Heightfield {
+	if resolution[0] < 2 || resolution[1] < 2 {
+		panic(fmt.Errorf("invalid heightfield resolution: %v", resolution))
+	}
+	if len(heights) != resolution[0]*resolution[1] {
+		panic(fmt.Errorf("heightfield of resolution %v needs %d heights, got %d",
+			resolution, resolution[0]*resolution[1], len(heights)))
+	}
+	if !(bounds.Min().X() < bounds.Max().X()) || !(bounds.Min().Z() < bounds.Max().Z()) || bounds.Min().Y() > bounds.Max().Y() {
+		panic(fmt.Errorf("heightfield bounds must have positive size along X and Z, got %v", bounds))
+	}
+
+	minHeight, maxHeight := core.Inf(), -core.Inf()
+	for _, height := range heights {
+		minHeight = core.Min(minHeight, height)
+		maxHeight = core.Max(maxHeight, height)
+	}
+
+	field := &Heightfield{
+		resolution: resolution,
+		bounds:     bounds,
+		heights:    heights,
+		cellSize: [2]core.Real{
+			(bounds.Max().X() - bounds.Min().X()) / core.Real(resolution[0]-1),
+			(bounds.Max().Z() - bounds.Min().Z()) / core.Real(resolution[1]-1),
+		},
+	}
+	field.boundingBox = core.NewBox(
+		core.NewVec3(bounds.Min().X(), field.y(minHeight), bounds.Min().Z()),
+		core.NewVec3(bounds.Max().X(), field.y(maxHeight), bounds.Max().Z()))
+	return field
+}
+
+func (h *Heightfield) BoundingBox() core.Box {
+	return h.boundingBox
+}
+
+// The bounding box is flat for flat terrains, which rays can't be clipped to.
+func (h *Heightfield) clipBox() core.Box {
+	padding := core.NewVec3(0, heightfieldClipPadding, 0)
+	return core.NewBox(h.boundingBox.Min().Sub(padding), h.boundingBox.Max().Add(padding))
+}
+
+// Walks the cells under the ray in order with a 2D DDA and tests the triangles of each cell,
+// so the first hit found is the closest one.
+// http://www.cse.yorku.ca/~amana/research/grid.pdf
+func (h *Heightfield) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	inside, ok := ray.Clip(h.clipBox(), params)
+	if !ok {
+		return optional.Empty[Hit]()
+	}
+
+	// The axes of the grid are X and Z
+	axes := [2]int{0, 2}
+	var cell, step [2]int
+	var nextCrossing, crossingDelta [2]core.Real
+	start := ray.Eval(inside.Min())
+	for i, axis := range axes {
+		relative := (start.At(axis) - h.bounds.Min().At(axis)) / h.cellSize[i]
+		cell[i] = clampIndex(int(core.Floor(relative)), h.resolution[i]-2)
+
+		direction := ray.Direction().At(axis)
+		if direction == 0 {
+			step[i], nextCrossing[i], crossingDelta[i] = 0, core.Inf(), core.Inf()
+			continue
+		}
+		step[i] = core.IfElse(direction > 0, 1, -1)
+		boundary := h.bounds.Min().At(axis) + h.cellSize[i]*core.Real(cell[i]+core.IfElse(direction > 0, 1, 0))
+		nextCrossing[i] = (boundary - ray.Origin().At(axis)) / direction
+		crossingDelta[i] = h.cellSize[i] / core.Abs(direction)
+	}
+
+	cellEntry := inside.Min()
+	for {
+		cellExit := core.Min(inside.Max(), core.Min(nextCrossing[0], nextCrossing[1]))
+		if hit := h.testCell(ray, params, cell, cellEntry, cellExit); hit.Present() {
+			return hit
+		}
+		if cellExit >= inside.Max() {
+			return optional.Empty[Hit]()
+		}
+
+		next := core.IfElse(nextCrossing[0] < nextCrossing[1], 0, 1)
+		cell[next] += step[next]
+		if cell[next] < 0 || cell[next] > h.resolution[next]-2 {
+			return optional.Empty[Hit]()
+		}
+		cellEntry = nextCrossing[next]
+		nextCrossing[next] += crossingDelta[next]
+	}
+}
+
+// Skips cells where the ray passes entirely above or below the terrain.
+func (h *Heightfield) testCell(ray core.Ray, params core.Interval, cell [2]int, entry, exit core.Real) optional.Optional[Hit] {
+	i, j := cell[0], cell[1]
+	h00, h10, h01, h11 := h.height(i, j), h.height(i+1, j), h.height(i, j+1), h.height(i+1, j+1)
+	low := h.y(core.Min(core.Min(h00, h10), core.Min(h01, h11)))
+	high := h.y(core.Max(core.Max(h00, h10), core.Max(h01, h11)))
+	entryY, exitY := ray.Eval(entry).Y(), ray.Eval(exit).Y()
+	if (entryY > high && exitY > high) || (entryY < low && exitY < low) {
+		return optional.Empty[Hit]()
+	}
+
+	v00, v10, v01, v11 := h.vertex(i, j), h.vertex(i+1, j), h.vertex(i, j+1), h.vertex(i+1, j+1)
+	n00, n10, n01, n11 := h.normal(i, j), h.normal(i+1, j), h.normal(i, j+1), h.normal(i+1, j+1)
+	uv00, uv10, uv01, uv11 := h.uv(i, j), h.uv(i+1, j), h.uv(i, j+1), h.uv(i+1, j+1)
+	first := NewTriangleWithNormals(v00, v01, v10, n00, n01, n10).WithUVs(uv00, uv01, uv10)
+	second := NewTriangleWithNormals(v11, v10, v01, n11, n10, n01).WithUVs(uv11, uv10, uv01)
+
+	hit := first.TestRay(ray, params)
+	if hit.Present() {
+		params = core.NewInterval(params.Min(), hit.Value().Param)
+	}
+	if secondHit := second.TestRay(ray, params); secondHit.Present() {
+		return secondHit
+	}
+	return hit
+}
+
+func (h *Heightfield) height(i, j int) core.Real {
+	return h.heights[j*h.resolution[0]+i]
+}
+
+func (h *Heightfield) y(height core.Real) core.Real {
+	return h.bounds.Min().Y() + height*(h.bounds.Max().Y()-h.bounds.Min().Y())
+}
+
+func (h *Heightfield) vertex(i, j int) core.Vec3 {
+	return core.NewVec3(
+		h.bounds.Min().X()+core.Real(i)*h.cellSize[0],
+		h.y(h.height(i, j)),
+		h.bounds.Min().Z()+core.Real(j)*h.cellSize[1])
+}
+
+// The slopes of the terrain by central differences, one-sided at the borders.
+func (h *Heightfield) normal(i, j int) core.Vec3 {
+	left, right := clampIndex(i-1, h.resolution[0]-1), clampIndex(i+1, h.resolution[0]-1)
+	back, front := clampIndex(j-1, h.resolution[1]-1), clampIndex(j+1, h.resolution[1]-1)
+	slopeX := (h.y(h.height(right, j)) - h.y(h.height(left, j))) / (core.Real(right-left) * h.cellSize[0])
+	slopeZ := (h.y(h.height(i, front)) - h.y(h.height(i, back))) / (core.Real(front-back) * h.cellSize[1])
+	return core.NewVec3(-slopeX, 1, -slopeZ).Normalize()
+}
+
+func (h *Heightfield) uv(i, j int) core.Vec2 {
+	return core.NewVec2(core.Real(i)/core.Real(h.resolution[0]-1), core.Real(j)/core.Real(h.resolution[1]-1))
+}
+
+func clampIndex(index, max int) int {
+	if index < 0 {
+		return 0
+	}
+	if index > max {
+		return max
+	}
+	return index
+}
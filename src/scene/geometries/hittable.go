@@ -12,8 +12,7 @@ type Hittable interface {
 }
 
 type Hit struct {
-	Param    core.Real
-	Point    core.Vec3
-	Normal   core.Vec3
+	Param core.Real
+	core.SurfacePoint
 	Material materials.Material
 }
@@ -0,0 +1,36 @@
+package geometries
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+// hittableList tests all of its hittables, it's used for small BVH leaves.
+type hittableList struct {
+	hittables   []Hittable
+	boundingBox core.Box
+}
+
+func newHittableList(hittables []Hittable) hittableList {
+	boundingBox := core.NewEmptyBox()
+	for _, hittable := range hittables {
+		boundingBox = boundingBox.Union(hittable.BoundingBox())
+	}
+	return hittableList{hittables: hittables, boundingBox: boundingBox}
+}
+
+func (l hittableList) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	closestHit := optional.Empty[Hit]()
+	for _, hittable := range l.hittables {
+		hit := hittable.TestRay(ray, params)
+		if hit.Present() {
+			closestHit = hit
+			params = core.NewInterval(params.Min(), hit.Value().Param)
+		}
+	}
+	return closestHit
+}
+
+func (l hittableList) BoundingBox() core.Box {
+	return l.boundingBox
+}
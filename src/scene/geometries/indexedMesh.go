@@ -0,0 +1,394 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+type NormalWeighting int
+
+const (
+	AreaWeighting NormalWeighting = iota
+	AngleWeighting
+)
+
+// IndexedMesh is a triangle mesh stored as shared vertex buffers and an index buffer.
+// Processing methods return new meshes and leave the original intact.
+// Build turns it into a Mesh whose BVH references faces by index, so each vertex
+// is stored once instead of once per triangle.
+type IndexedMesh struct {
+	positions []core.Vec3
+	normals   []core.Vec3 // nil for flat shading
+	uvs       []core.Vec2 // nil for the default triangle UVs
+	faces     [][3]int
+}
+
+func NewIndexedMesh(positions []core.Vec3, faces [][3]int) *IndexedMesh {
+	for i, face := range faces {
+		for _, index := range face {
+			if index < 0 || index >= len(positions) {
+				panic(fmt.Errorf("indexed mesh: face %d: vertex index %d out of range [0, %d)", i, index, len(positions)))
+			}
+		}
+	}
+	return &IndexedMesh{positions: positions, faces: faces}
+}
+
+// WithNormals returns a copy of the mesh with the given vertex normals, nil means flat shading.
+func (m *IndexedMesh) WithNormals(normals []core.Vec3) *IndexedMesh {
+	if normals != nil && len(normals) != len(m.positions) {
+		panic(fmt.Errorf("indexed mesh: %d normals for %d vertices", len(normals), len(m.positions)))
+	}
+	mesh := *m
+	mesh.normals = normals
+	return &mesh
+}
+
+// WithUVs returns a copy of the mesh with the given vertex texture coordinates.
+func (m *IndexedMesh) WithUVs(uvs []core.Vec2) *IndexedMesh {
+	if uvs != nil && len(uvs) != len(m.positions) {
+		panic(fmt.Errorf("indexed mesh: %d UVs for %d vertices", len(uvs), len(m.positions)))
+	}
+	mesh := *m
+	mesh.uvs = uvs
+	return &mesh
+}
+
+func (m *IndexedMesh) Positions() []core.Vec3 {
+	return m.positions
+}
+
+func (m *IndexedMesh) Normals() []core.Vec3 {
+	return m.normals
+}
+
+func (m *IndexedMesh) UVs() []core.Vec2 {
+	return m.uvs
+}
+
+func (m *IndexedMesh) Faces() [][3]int {
+	return m.faces
+}
+
+// Weld merges vertices closer than the tolerance whose normals and UVs, if any, also agree
+// within the tolerance. Faces collapsed by merging are removed.
+// Vertices are bucketed in a grid with the tolerance as cell size, so only neighbouring cells are compared.
+func (m *IndexedMesh) Weld(tolerance core.Real) *IndexedMesh {
+	if !(tolerance > 0) {
+		panic(fmt.Errorf("indexed mesh: weld tolerance must be positive, got %v", tolerance))
+	}
+
+	welded := &IndexedMesh{}
+	cells := map[[3]int][]int{}
+	remap := make([]int, len(m.positions))
+	for i, position := range m.positions {
+		cell := [3]int{}
+		for axis := range cell {
+			cell[axis] = int(core.Floor(position.At(axis) / tolerance))
+		}
+
+		remap[i] = -1
+		for _, neighbour := range neighbourCells(cell) {
+			for _, candidate := range cells[neighbour] {
+				if m.sameVertex(i, welded, candidate, tolerance) {
+					remap[i] = candidate
+					break
+				}
+			}
+			if remap[i] >= 0 {
+				break
+			}
+		}
+		if remap[i] < 0 {
+			remap[i] = welded.copyVertex(m, i)
+			cells[cell] = append(cells[cell], remap[i])
+		}
+	}
+
+	for _, face := range m.faces {
+		face = [3]int{remap[face[0]], remap[face[1]], remap[face[2]]}
+		if face[0] != face[1] && face[1] != face[2] && face[2] != face[0] {
+			welded.faces = append(welded.faces, face)
+		}
+	}
+	return welded
+}
+
+func neighbourCells(cell [3]int) [27][3]int {
+	var neighbours [27][3]int
+	index := 0
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dz := -1; dz <= 1; dz++ {
+				neighbours[index] = [3]int{cell[0] + dx, cell[1] + dy, cell[2] + dz}
+				index++
+			}
+		}
+	}
+	return neighbours
+}
+
+func (m *IndexedMesh) sameVertex(index int, other *IndexedMesh, otherIndex int, tolerance core.Real) bool {
+	if !m.positions[index].InDelta(other.positions[otherIndex], tolerance) {
+		return false
+	}
+	if m.normals != nil && !m.normals[index].InDelta(other.normals[otherIndex], tolerance) {
+		return false
+	}
+	return m.uvs == nil || m.uvs[index].InDelta(other.uvs[otherIndex], tolerance)
+}
+
+// copyVertex appends a vertex of the source mesh with all its attributes and returns its index.
+func (m *IndexedMesh) copyVertex(source *IndexedMesh, index int) int {
+	m.positions = append(m.positions, source.positions[index])
+	if source.normals != nil {
+		m.normals = append(m.normals, source.normals[index])
+	}
+	if source.uvs != nil {
+		m.uvs = append(m.uvs, source.uvs[index])
+	}
+	return len(m.positions) - 1
+}
+
+type faceCorner struct {
+	face, corner int
+}
+
+type smoothVertex struct {
+	index  int
+	normal core.Vec3
+}
+
+// SmoothNormals computes vertex normals by averaging the normals of the faces around each vertex,
+// weighted by face area or by the face angle at the vertex. Faces meeting at more than the crease
+// angle in degrees don't smooth each other, vertices on such creases are split to keep the edge sharp.
+// https://www.bytehazard.com/articles/vertnorm.html
+func (m *IndexedMesh) SmoothNormals(creaseAngle core.Real, weighting NormalWeighting) *IndexedMesh {
+	if weighting != AreaWeighting && weighting != AngleWeighting {
+		panic(fmt.Errorf("indexed mesh: invalid normal weighting: %d", weighting))
+	}
+
+	faceNormals := make([]core.Vec3, len(m.faces))
+	cornerWeights := make([][3]core.Real, len(m.faces))
+	vertexCorners := make([][]faceCorner, len(m.positions))
+	for f, face := range m.faces {
+		cross := m.positions[face[1]].Sub(m.positions[face[0]]).Cross(m.positions[face[2]].Sub(m.positions[face[0]]))
+		if cross.LenSqr() == 0 {
+			continue
+		}
+		faceNormals[f] = cross.Normalize()
+		for corner, vertex := range face {
+			vertexCorners[vertex] = append(vertexCorners[vertex], faceCorner{f, corner})
+			if weighting == AreaWeighting {
+				cornerWeights[f][corner] = cross.Len() / 2
+			} else {
+				cornerWeights[f][corner] = m.cornerAngle(face, corner)
+			}
+		}
+	}
+
+	cosCrease := core.Cos(creaseAngle * core.Pi / 180)
+	smoothed := &IndexedMesh{faces: make([][3]int, len(m.faces))}
+	vertexIndex := map[smoothVertex]int{}
+	for f, face := range m.faces {
+		for corner, vertex := range face {
+			normal := core.Vec3{}
+			for _, other := range vertexCorners[vertex] {
+				// Degenerate faces have no normal of their own and take the average of all faces around
+				similarity := faceNormals[f].Dot(faceNormals[other.face])
+				if faceNormals[f].LenSqr() == 0 || creaseAngle >= 180 || similarity >= cosCrease {
+					normal = normal.Add(faceNormals[other.face].Mul(cornerWeights[other.face][other.corner]))
+				}
+			}
+			if normal.LenSqr() > 0 {
+				normal = normal.Normalize()
+			}
+
+			key := smoothVertex{vertex, normal}
+			index, ok := vertexIndex[key]
+			if !ok {
+				index = len(smoothed.positions)
+				vertexIndex[key] = index
+				smoothed.positions = append(smoothed.positions, m.positions[vertex])
+				smoothed.normals = append(smoothed.normals, normal)
+				if m.uvs != nil {
+					smoothed.uvs = append(smoothed.uvs, m.uvs[vertex])
+				}
+			}
+			smoothed.faces[f][corner] = index
+		}
+	}
+	return smoothed
+}
+
+func (m *IndexedMesh) cornerAngle(face [3]int, corner int) core.Real {
+	vertex := m.positions[face[corner]]
+	edge1 := m.positions[face[(corner+1)%3]].Sub(vertex)
+	edge2 := m.positions[face[(corner+2)%3]].Sub(vertex)
+	return core.Atan2(edge1.Cross(edge2).Len(), edge1.Dot(edge2))
+}
+
+type loopEdge struct {
+	a, b      int
+	opposites []int
+}
+
+func (e *loopEdge) isBoundary() bool {
+	return len(e.opposites) != 2
+}
+
+// Subdivide applies the given number of Loop subdivision steps, each splitting every face into four.
+// Boundary edges, including UV seams and edges shared by more than two faces, follow the boundary curve rules.
+// New UVs are interpolated linearly, vertex normals are dropped and can be recomputed with SmoothNormals.
+// https://en.wikipedia.org/wiki/Loop_subdivision_surface
+func (m *IndexedMesh) Subdivide(levels int) *IndexedMesh {
+	if levels < 0 {
+		panic(fmt.Errorf("indexed mesh: invalid subdivision levels: %d", levels))
+	}
+	mesh := m
+	for i := 0; i < levels; i++ {
+		mesh = mesh.loopSubdivision()
+	}
+	return mesh
+}
+
+func (m *IndexedMesh) loopSubdivision() *IndexedMesh {
+	edges := []*loopEdge{}
+	edgeIndex := map[[2]int]int{}
+	faceEdges := make([][3]int, len(m.faces))
+	for f, face := range m.faces {
+		for corner := 0; corner < 3; corner++ {
+			a, b := face[corner], face[(corner+1)%3]
+			key := core.IfElse(a < b, [2]int{a, b}, [2]int{b, a})
+			index, ok := edgeIndex[key]
+			if !ok {
+				index = len(edges)
+				edgeIndex[key] = index
+				edges = append(edges, &loopEdge{a: a, b: b})
+			}
+			edges[index].opposites = append(edges[index].opposites, face[(corner+2)%3])
+			faceEdges[f][corner] = index
+		}
+	}
+
+	neighbours := make([][]int, len(m.positions))
+	boundaryNeighbours := make([][]int, len(m.positions))
+	for _, edge := range edges {
+		neighbours[edge.a] = append(neighbours[edge.a], edge.b)
+		neighbours[edge.b] = append(neighbours[edge.b], edge.a)
+		if edge.isBoundary() {
+			boundaryNeighbours[edge.a] = append(boundaryNeighbours[edge.a], edge.b)
+			boundaryNeighbours[edge.b] = append(boundaryNeighbours[edge.b], edge.a)
+		}
+	}
+
+	// Original vertices keep their indices, edge vertices follow them
+	subdivided := &IndexedMesh{positions: make([]core.Vec3, 0, len(m.positions)+len(edges))}
+	for i, position := range m.positions {
+		subdivided.positions = append(subdivided.positions, m.loopVertex(position, neighbours[i], boundaryNeighbours[i]))
+	}
+	for _, edge := range edges {
+		subdivided.positions = append(subdivided.positions, m.loopEdgeVertex(edge))
+	}
+	if m.uvs != nil {
+		subdivided.uvs = append(make([]core.Vec2, 0, len(subdivided.positions)), m.uvs...)
+		for _, edge := range edges {
+			subdivided.uvs = append(subdivided.uvs, m.uvs[edge.a].Add(m.uvs[edge.b]).Mul(0.5))
+		}
+	}
+
+	subdivided.faces = make([][3]int, 0, 4*len(m.faces))
+	for f, face := range m.faces {
+		ab := len(m.positions) + faceEdges[f][0]
+		bc := len(m.positions) + faceEdges[f][1]
+		ca := len(m.positions) + faceEdges[f][2]
+		subdivided.faces = append(subdivided.faces,
+			[3]int{face[0], ab, ca},
+			[3]int{ab, face[1], bc},
+			[3]int{ca, bc, face[2]},
+			[3]int{ab, bc, ca})
+	}
+	return subdivided
+}
+
+// Interior vertices use Warren's weights, boundary vertices only follow the boundary curve
+// and vertices where several boundaries meet stay in place.
+func (m *IndexedMesh) loopVertex(position core.Vec3, neighbours, boundaryNeighbours []int) core.Vec3 {
+	switch {
+	case len(boundaryNeighbours) == 0 && len(neighbours) > 0:
+		n := len(neighbours)
+		beta := core.IfElse(n == 3, core.Real(3)/16, core.Real(3)/core.Real(8*n))
+		sum := core.Vec3{}
+		for _, neighbour := range neighbours {
+			sum = sum.Add(m.positions[neighbour])
+		}
+		return position.Mul(1 - core.Real(n)*beta).Add(sum.Mul(beta))
+	case len(boundaryNeighbours) == 2:
+		sum := m.positions[boundaryNeighbours[0]].Add(m.positions[boundaryNeighbours[1]])
+		return position.Mul(0.75).Add(sum.Mul(0.125))
+	default:
+		return position
+	}
+}
+
+func (m *IndexedMesh) loopEdgeVertex(edge *loopEdge) core.Vec3 {
+	ends := m.positions[edge.a].Add(m.positions[edge.b])
+	if edge.isBoundary() {
+		return ends.Mul(0.5)
+	}
+	opposites := m.positions[edge.opposites[0]].Add(m.positions[edge.opposites[1]])
+	return ends.Mul(0.375).Add(opposites.Mul(0.125))
+}
+
+// Build creates a Mesh referencing the buffers of this mesh. Degenerate faces are skipped.
+func (m *IndexedMesh) Build(settings ...BVHSetting) Mesh {
+	faces := make([][3]int, 0, len(m.faces))
+	for _, face := range m.faces {
+		v0, v1, v2 := m.positions[face[0]], m.positions[face[1]], m.positions[face[2]]
+		if v1.Sub(v0).Cross(v2.Sub(v0)).LenSqr() > 0 {
+			faces = append(faces, face)
+		}
+	}
+	mesh := *m
+	mesh.faces = faces
+	return newMesh(&mesh, settings...)
+}
+
+func (m *IndexedMesh) triangleCount() int {
+	return len(m.faces)
+}
+
+func (m *IndexedMesh) triangle(index int) Triangle {
+	face := m.faces[index]
+	v0, v1, v2 := m.positions[face[0]], m.positions[face[1]], m.positions[face[2]]
+	triangle := NewTriangle(v0, v1, v2)
+	if m.normals != nil {
+		triangle = NewTriangleWithNormals(v0, v1, v2, m.normals[face[0]], m.normals[face[1]], m.normals[face[2]])
+	}
+	if m.uvs != nil {
+		triangle = triangle.WithUVs(m.uvs[face[0]], m.uvs[face[1]], m.uvs[face[2]])
+	}
+	return triangle
+}
+
+func (m *IndexedMesh) hittable(index int) Hittable {
+	return indexedTriangle{m, index}
+}
+
+// indexedTriangle is the BVH primitive of indexed meshes, it assembles its triangle on each test.
+type indexedTriangle struct {
+	mesh *IndexedMesh
+	face int
+}
+
+func (t indexedTriangle) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	return t.mesh.triangle(t.face).TestRay(ray, params)
+}
+
+func (t indexedTriangle) BoundingBox() core.Box {
+	face := t.mesh.faces[t.face]
+	v0, v1, v2 := t.mesh.positions[face[0]], t.mesh.positions[face[1]], t.mesh.positions[face[2]]
+	return core.NewBox(core.Vec3Min(core.Vec3Min(v0, v1), v2), core.Vec3Max(core.Vec3Max(v0, v1), v2))
+}
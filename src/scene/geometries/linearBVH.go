@@ -0,0 +1,138 @@
+package geometries
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+// Initial traversal stack capacity, enough for balanced trees of any practical size.
+const linearBVHStackSize = 64
+
+// LinearBVH is a BVH flattened into an array in depth-first order, so that the first
+// child of a node follows the node itself and only the second child offset is stored.
+// Leaves reference ranges of a contiguous primitive array.
+// https://www.pbr-book.org/3ed-2018/Primitives_and_Intersection_Acceleration/Bounding_Volume_Hierarchies#CompactBVHForTraversal
+type LinearBVH struct {
+	nodes      []linearBVHNode
+	primitives []Hittable
+	stats      BVHStats
+}
+
+type linearBVHNode struct {
+	boundingBox core.Box
+	offset      int // first primitive for leaves, second child for interior nodes
+	count       int // number of primitives for leaves, -1 for interior nodes
+	axis        int // interior nodes: the first child lies lower along this axis
+}
+
+func (n *linearBVHNode) isLeaf() bool {
+	return n.count >= 0
+}
+
+func NewLinearBVH(hittables []Hittable, settings ...BVHSetting) *LinearBVH {
+	return FlattenBVH(BuildBVH(hittables, settings...))
+}
+
+func FlattenBVH(root *BVHNode) *LinearBVH {
+	stats := root.Stats()
+	bvh := &LinearBVH{
+		nodes:      make([]linearBVHNode, 0, stats.NodeCount),
+		primitives: make([]Hittable, 0, stats.LeafCount),
+		stats:      stats,
+	}
+	bvh.flatten(root)
+	return bvh
+}
+
+func (bvh *LinearBVH) flatten(node *BVHNode) {
+	index := len(bvh.nodes)
+	bvh.nodes = append(bvh.nodes, linearBVHNode{boundingBox: node.boundingBox})
+
+	if node.isLeaf() {
+		bvh.nodes[index].offset = len(bvh.primitives)
+		bvh.primitives = append(bvh.primitives, leafHittables(node)...)
+		bvh.nodes[index].count = len(bvh.primitives) - bvh.nodes[index].offset
+		return
+	}
+
+	first, second := node.leftChild.(*BVHNode), node.rightChild.(*BVHNode)
+	axis := separatingAxis(first.boundingBox, second.boundingBox)
+	if second.boundingBox.Center().At(axis) < first.boundingBox.Center().At(axis) {
+		first, second = second, first
+	}
+
+	bvh.nodes[index].axis = axis
+	bvh.nodes[index].count = -1
+	bvh.flatten(first)
+	bvh.nodes[index].offset = len(bvh.nodes)
+	bvh.flatten(second)
+}
+
+func leafHittables(leaf *BVHNode) []Hittable {
+	switch child := leaf.leftChild.(type) {
+	case emptyHittable:
+		return nil
+	case hittableList:
+		return child.hittables
+	default:
+		return []Hittable{child}
+	}
+}
+
+// The axis along which the centers of two boxes are the farthest apart.
+func separatingAxis(a, b core.Box) int {
+	distance := b.Center().Sub(a.Center())
+	distanceX := core.Abs(finiteOrZero(distance.X()))
+	distanceY := core.Abs(finiteOrZero(distance.Y()))
+	distanceZ := core.Abs(finiteOrZero(distance.Z()))
+	if distanceX >= distanceY && distanceX >= distanceZ {
+		return 0
+	}
+	return core.IfElse(distanceY >= distanceZ, 1, 2)
+}
+
+// Children are visited front to back, and the parameter interval shrinks with every hit,
+// so that subtrees behind the closest hit found so far are skipped.
+func (bvh *LinearBVH) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	closestHit := optional.Empty[Hit]()
+	directionIsNegative := [3]bool{ray.Direction().X() < 0, ray.Direction().Y() < 0, ray.Direction().Z() < 0}
+
+	stack := make([]int, 0, linearBVHStackSize)
+	current := 0
+	for {
+		node := &bvh.nodes[current]
+		if ray.Hits(node.boundingBox, params) {
+			if node.isLeaf() {
+				for _, primitive := range bvh.primitives[node.offset : node.offset+node.count] {
+					hit := primitive.TestRay(ray, params)
+					if hit.Present() {
+						closestHit = hit
+						params = core.NewInterval(params.Min(), hit.Value().Param)
+					}
+				}
+			} else if directionIsNegative[node.axis] {
+				stack = append(stack, current+1)
+				current = node.offset
+				continue
+			} else {
+				stack = append(stack, node.offset)
+				current = current + 1
+				continue
+			}
+		}
+
+		if len(stack) == 0 {
+			return closestHit
+		}
+		current = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+	}
+}
+
+func (bvh *LinearBVH) BoundingBox() core.Box {
+	return bvh.nodes[0].boundingBox
+}
+
+func (bvh *LinearBVH) Stats() BVHStats {
+	return bvh.stats
+}
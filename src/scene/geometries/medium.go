@@ -0,0 +1,31 @@
+package geometries
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+// Medium hittables are participating media, like fog, smoke or clouds. Rays hit them at random
+// points inside, and light passing through them is attenuated rather than blocked.
+// Their object materials should be phase functions, like materials.HenyeyGreenstein.
+type Medium interface {
+	Hittable
+	// Transmittance estimates the fraction of light travelling along the ray within the params.
+	Transmittance(ray core.Ray, params core.Interval) core.Real
+}
+
+// Distance to the next interaction in a medium of constant density.
+func sampleFreeFlight(density core.Real, randomizer random.RandomGenerator) core.Real {
+	return -core.Log(1-randomizer.Real()) / density
+}
+
+// Media have no surface, the normal faces the ray for materials that need one.
+func mediumHit(ray core.Ray, hitParam core.Real) Hit {
+	return Hit{
+		Param: hitParam,
+		SurfacePoint: core.SurfacePoint{
+			Point:  ray.Eval(hitParam),
+			Normal: ray.Direction().Normalize().Mul(-1),
+		},
+	}
+}
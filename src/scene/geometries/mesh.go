@@ -2,23 +2,53 @@ package geometries
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 )
 
 type Mesh struct {
-	trianglesBHV *BVHNode
+	trianglesBHV *LinearBVH
+	triangles    triangleSource
+	// Running sums of triangle areas, used to pick triangles for light sampling
+	cumulativeAreas []core.Real
 }
 
-func NewMesh(triangles []Triangle) Mesh {
-	hittables := make([]Hittable, 0, len(triangles))
-	for _, triangle := range triangles {
-		hittables = append(hittables, triangle)
+// triangleSource provides the triangles of a mesh by index, either stored or assembled on demand.
+type triangleSource interface {
+	triangleCount() int
+	triangle(index int) Triangle
+	// The hittable stored in the BVH for the triangle
+	hittable(index int) Hittable
+}
+
+type triangleSlice []Triangle
+
+func (t triangleSlice) triangleCount() int          { return len(t) }
+func (t triangleSlice) triangle(index int) Triangle { return t[index] }
+func (t triangleSlice) hittable(index int) Hittable { return t[index] }
+
+func NewMesh(triangles []Triangle, settings ...BVHSetting) Mesh {
+	return newMesh(triangleSlice(triangles), settings...)
+}
+
+func newMesh(triangles triangleSource, settings ...BVHSetting) Mesh {
+	count := triangles.triangleCount()
+	hittables := make([]Hittable, 0, count)
+	cumulativeAreas := make([]core.Real, 0, count)
+	totalArea := core.Real(0)
+	for i := 0; i < count; i++ {
+		hittables = append(hittables, triangles.hittable(i))
+		totalArea += triangles.triangle(i).area()
+		cumulativeAreas = append(cumulativeAreas, totalArea)
 	}
 
 	return Mesh{
-		trianglesBHV: BuildBVH(hittables),
+		trianglesBHV:    NewLinearBVH(hittables, settings...),
+		triangles:       triangles,
+		cumulativeAreas: cumulativeAreas,
 	}
 }
 
@@ -30,14 +60,47 @@ func (m Mesh) BoundingBox() core.Box {
 	return m.trianglesBHV.BoundingBox()
 }
 
+// Sample picks a point uniformly over the mesh area.
+func (m Mesh) Sample(origin core.Vec3, randomizer random.RandomGenerator) SurfaceSample {
+	if len(m.cumulativeAreas) == 0 {
+		return SurfaceSample{}
+	}
+
+	target := randomizer.Real() * m.area()
+	index := sort.Search(len(m.cumulativeAreas), func(i int) bool {
+		return m.cumulativeAreas[i] > target
+	})
+	triangle := m.triangles.triangle(core.IfElse(index < len(m.cumulativeAreas), index, len(m.cumulativeAreas)-1))
+
+	surface := triangle.sampleSurface(randomizer)
+	return SurfaceSample{
+		SurfacePoint: surface,
+		PDF:          areaToSolidAnglePDF(1/m.area(), origin, surface.Point, surface.Normal),
+	}
+}
+
+// PDF uses the shading normal at the hit, which matches the geometric normal for flat meshes.
+func (m Mesh) PDF(origin core.Vec3, hit Hit) core.Real {
+	if len(m.cumulativeAreas) == 0 {
+		return 0
+	}
+	return areaToSolidAnglePDF(1/m.area(), origin, hit.Point, hit.Normal)
+}
+
+func (m Mesh) area() core.Real {
+	return m.cumulativeAreas[len(m.cumulativeAreas)-1]
+}
+
 func NewQuad(a, b, c, d core.Vec3) Mesh {
 	norm1 := core.Normal(a, b, c)
 	norm2 := core.Normal(c, d, a)
 	if !norm1.InDelta(norm2, core.Tolerance) {
 		panic(fmt.Errorf("make quad: normals don't match: %v and %v", norm1, norm2))
 	}
-	triangle1 := NewTriangle(a, b, c)
-	triangle2 := NewTriangle(c, d, a)
+	// The quad spans the unit square in UV space
+	uvA, uvB, uvC, uvD := core.NewVec2(0, 0), core.NewVec2(1, 0), core.NewVec2(1, 1), core.NewVec2(0, 1)
+	triangle1 := NewTriangle(a, b, c).WithUVs(uvA, uvB, uvC)
+	triangle2 := NewTriangle(c, d, a).WithUVs(uvC, uvD, uvA)
 
 	return NewMesh([]Triangle{triangle1, triangle2})
 }
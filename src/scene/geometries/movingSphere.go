@@ -0,0 +1,38 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+// MovingSphere moves linearly from center0 at time0 to center1 at time1.
+// Before time0 and after time1 it stays at the end points.
+// https://raytracing.github.io/books/RayTracingTheNextWeek.html#motionblur
+type MovingSphere struct {
+	center0, center1 core.Vec3
+	time0, time1     core.Real
+	radius           core.Real
+}
+
+func NewMovingSphere(center0, center1 core.Vec3, time0, time1, radius core.Real) MovingSphere {
+	if time1 <= time0 {
+		panic(fmt.Errorf("new moving sphere: time1 %v must be after time0 %v", time1, time0))
+	}
+	return MovingSphere{center0: center0, center1: center1, time0: time0, time1: time1, radius: radius}
+}
+
+func (sphere MovingSphere) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	return sphere.at(ray.Time()).TestRay(ray, params)
+}
+
+func (sphere MovingSphere) BoundingBox() core.Box {
+	return sphere.at(sphere.time0).BoundingBox().Union(sphere.at(sphere.time1).BoundingBox())
+}
+
+func (sphere MovingSphere) at(time core.Real) Sphere {
+	progress := core.Max(0, core.Min(1, (time-sphere.time0)/(sphere.time1-sphere.time0)))
+	center := sphere.center0.Add(sphere.center1.Sub(sphere.center0).Mul(progress))
+	return NewSphere(center, sphere.radius)
+}
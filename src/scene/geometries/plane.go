@@ -0,0 +1,65 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+// Plane is an infinite plane through the point. UVs are the coordinates along the plane
+// in world units, so that repeating textures tile it.
+type Plane struct {
+	point core.Vec3
+	frame axisFrame
+}
+
+func NewPlane(point, normal core.Vec3) Plane {
+	if normal.LenSqr() == 0 {
+		panic(fmt.Errorf("new plane: normal must not be zero"))
+	}
+	return Plane{point: point, frame: newAxisFrame(normal)}
+}
+
+func (p Plane) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	hitParam, crosses := p.frame.planeParam(ray, p.point)
+	if !crosses || !params.Contains(hitParam) {
+		return optional.Empty[Hit]()
+	}
+
+	point := ray.Eval(hitParam)
+	offset := point.Sub(p.point)
+	return optional.Of(Hit{
+		Param: hitParam,
+		SurfacePoint: core.SurfacePoint{
+			Point:     point,
+			Normal:    p.frame.axis,
+			UV:        core.NewVec2(offset.Dot(p.frame.x), offset.Dot(p.frame.z)),
+			Tangent:   p.frame.x,
+			Bitangent: p.frame.z,
+		},
+	})
+}
+
+// Planes orthogonal to a world axis are flat along it, others are unbounded.
+func (p Plane) BoundingBox() core.Box {
+	box := core.NewInfiniteBox()
+	for axis := 0; axis < 3; axis++ {
+		if core.Abs(p.frame.axis.At(axis)) == 1 {
+			min, max := box.Min(), box.Max()
+			return core.NewBox(withComponent(min, axis, p.point.At(axis)), withComponent(max, axis, p.point.At(axis)))
+		}
+	}
+	return box
+}
+
+func withComponent(v core.Vec3, axis int, value core.Real) core.Vec3 {
+	switch axis {
+	case 0:
+		return core.NewVec3(value, v.Y(), v.Z())
+	case 1:
+		return core.NewVec3(v.X(), value, v.Z())
+	default:
+		return core.NewVec3(v.X(), v.Y(), value)
+	}
+}
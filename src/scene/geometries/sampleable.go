@@ -0,0 +1,33 @@
+package geometries
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+// SurfaceSample is a random point on a hittable surface.
+// The probability density is with respect to the solid angle seen from the sampling origin.
+type SurfaceSample struct {
+	core.SurfacePoint
+	PDF core.Real
+}
+
+// Sampleable hittables can be used as area light sources.
+type Sampleable interface {
+	Hittable
+	// Sample picks a random point on the surface, preferably one visible from the origin.
+	Sample(origin core.Vec3, randomizer random.RandomGenerator) SurfaceSample
+	// PDF returns the density with which Sample picks the hit point of a ray starting at the origin.
+	PDF(origin core.Vec3, hit Hit) core.Real
+}
+
+// Converts a density with respect to surface area to a density with respect to solid angle.
+func areaToSolidAnglePDF(areaPDF core.Real, origin, point, normal core.Vec3) core.Real {
+	toPoint := point.Sub(origin)
+	distanceSquared := toPoint.LenSqr()
+	cosine := core.Abs(normal.Dot(toPoint)) / core.Sqrt(distanceSquared)
+	if cosine == 0 {
+		return 0
+	}
+	return areaPDF * distanceSquared / cosine
+}
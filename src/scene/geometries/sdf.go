@@ -0,0 +1,107 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+const (
+	// Sphere tracing steps at least this far, so that it crosses the surface instead of approaching it forever
+	sdfMinStep = 1e-4
+	// The crossing is located by halving the last step this many times
+	sdfBisectionSteps = 16
+	// Normals are the gradient of the distance, computed by central differences with this step
+	sdfNormalStep = 1e-3
+)
+
+// SDF renders the zero level set of a distance function inside the bounds by sphere tracing.
+// Like other hittables, it can be put into a BVH.
+// https://iquilezles.org/articles/raymarchingdf/
+type SDF struct {
+	distance DistanceFunction
+	bounds   core.Box
+	settings sdfSettings
+}
+
+func NewSDF(distance DistanceFunction, bounds core.Box, settings ...SDFSetting) SDF {
+	if bounds.Empty() {
+		panic(fmt.Errorf("new SDF: bounds are empty: %v", bounds))
+	}
+	sdf := SDF{distance: distance, bounds: bounds, settings: defaultSDFSettings()}
+	for _, setting := range settings {
+		setting(&sdf.settings)
+	}
+	return sdf
+}
+
+// Steps as far as the distance to the surface allows and looks for a change of the distance sign,
+// so that rays starting inside the shape find its surface too.
+func (s SDF) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	inside, ok := ray.Clip(s.bounds, params)
+	if !ok {
+		return optional.Empty[Hit]()
+	}
+
+	directionLength := ray.Direction().Len()
+	param := inside.Min()
+	distance := s.distance(ray.Eval(param))
+	for i := 0; i < s.settings.maxSteps && param < inside.Max(); i++ {
+		step := core.Max(core.Abs(distance)*s.settings.stepScale, sdfMinStep) / directionLength
+		nextParam := core.Min(param+step, inside.Max())
+		nextDistance := s.distance(ray.Eval(nextParam))
+		if (distance < 0) != (nextDistance < 0) {
+			return optional.Of(s.evaluateHit(ray, s.bisect(ray, param, nextParam, distance)))
+		}
+		param, distance = nextParam, nextDistance
+	}
+	return optional.Empty[Hit]()
+}
+
+func (s SDF) bisect(ray core.Ray, low, high, lowDistance core.Real) core.Real {
+	for i := 0; i < sdfBisectionSteps; i++ {
+		middle := (low + high) / 2
+		middleDistance := s.distance(ray.Eval(middle))
+		if (middleDistance < 0) == (lowDistance < 0) {
+			low, lowDistance = middle, middleDistance
+		} else {
+			high = middle
+		}
+	}
+	return high
+}
+
+func (s SDF) evaluateHit(ray core.Ray, hitParam core.Real) Hit {
+	point := ray.Eval(hitParam)
+	normal := s.normal(point)
+	tangent, bitangent := core.OrthonormalBasis(normal)
+	return Hit{
+		Param: hitParam,
+		SurfacePoint: core.SurfacePoint{
+			Point:     point,
+			Normal:    normal,
+			UV:        sphericalUV(normal),
+			Tangent:   tangent,
+			Bitangent: bitangent,
+		},
+	}
+}
+
+func (s SDF) normal(point core.Vec3) core.Vec3 {
+	difference := func(offset core.Vec3) core.Real {
+		return s.distance(point.Add(offset)) - s.distance(point.Sub(offset))
+	}
+	gradient := core.NewVec3(
+		difference(core.NewVec3(sdfNormalStep, 0, 0)),
+		difference(core.NewVec3(0, sdfNormalStep, 0)),
+		difference(core.NewVec3(0, 0, sdfNormalStep)))
+	if gradient.LenSqr() == 0 {
+		return core.NewVec3(0, 1, 0)
+	}
+	return gradient.Normalize()
+}
+
+func (s SDF) BoundingBox() core.Box {
+	return s.bounds
+}
@@ -0,0 +1,44 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+)
+
+const DEFAULT_SDF_MAX_STEPS = 512
+
+type sdfSettings struct {
+	maxSteps  int
+	stepScale core.Real
+}
+
+func defaultSDFSettings() sdfSettings {
+	return sdfSettings{
+		maxSteps:  DEFAULT_SDF_MAX_STEPS,
+		stepScale: 1,
+	}
+}
+
+type SDFSetting func(*sdfSettings)
+
+// SDFMaxSteps limits the number of steps along a ray, rays that don't reach the surface miss it.
+func SDFMaxSteps(steps int) SDFSetting {
+	if steps < 1 {
+		panic(fmt.Errorf("invalid SDF max steps: %d", steps))
+	}
+	return func(settings *sdfSettings) {
+		settings.maxSteps = steps
+	}
+}
+
+// SDFStepScale shortens the steps for distance functions that overestimate the distance,
+// like twisted ones, so that the surface isn't stepped over.
+func SDFStepScale(scale core.Real) SDFSetting {
+	if scale <= 0 || scale > 1 {
+		panic(fmt.Errorf("invalid SDF step scale: %v", scale))
+	}
+	return func(settings *sdfSettings) {
+		settings.stepScale = scale
+	}
+}
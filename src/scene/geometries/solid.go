@@ -0,0 +1,61 @@
+package geometries
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+)
+
+// Consecutive boundary crossings are searched this far apart, so that the same crossing isn't found again.
+const minCrossingGap = 1e-4
+
+// Limits the crossings walked along a ray, in case a hittable keeps reporting the same crossing.
+const maxSpanCrossings = 1024
+
+// Span is a part of a ray inside a solid, between the hits where the ray enters and leaves it.
+type Span struct {
+	Entry, Exit Hit
+}
+
+// Solid hittables know all parts of a ray inside them, not only the nearest hit.
+type Solid interface {
+	Hittable
+	// Spans returns the disjoint parts of the whole ray line inside the solid, ordered along the ray.
+	Spans(ray core.Ray) []Span
+}
+
+// Spans returns the parts of the ray line inside a closed hittable. Hittables that aren't solids
+// are tested repeatedly along the ray, the ray enters them where it hits the outer side of
+// the surface and leaves where it hits the inner side, as told by the normals.
+func Spans(hittable Hittable, ray core.Ray) []Span {
+	if solid, isSolid := hittable.(Solid); isSolid {
+		return solid.Spans(ray)
+	}
+
+	spans := []Span{}
+	var entry Hit
+	inside := false
+	params := core.NewInterval(-core.Inf(), core.Inf())
+	for i := 0; i < maxSpanCrossings; i++ {
+		optionalHit := hittable.TestRay(ray, params)
+		if optionalHit.Empty() {
+			return spans
+		}
+
+		hit := optionalHit.Value()
+		entering := hit.Normal.Dot(ray.Direction()) < 0
+		if entering && !inside {
+			entry = hit
+		} else if !entering && inside {
+			spans = append(spans, Span{Entry: entry, Exit: hit})
+		}
+		inside = entering
+		params = core.NewInterval(nextCrossingParam(ray, hit.Param), core.Inf())
+	}
+	return spans
+}
+
+// nextCrossingParam is where the search for the next boundary crossing starts after a crossing at the param.
+// The gap is a fixed distance along the ray, but at least a step to the next Real, since far from
+// the ray origin the gap is lost in rounding.
+func nextCrossingParam(ray core.Ray, param core.Real) core.Real {
+	return core.Max(param+minCrossingGap/ray.Direction().Len(), core.Nextafter(param, core.Inf()))
+}
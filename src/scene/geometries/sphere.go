@@ -3,6 +3,7 @@ package geometries
 import (
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 )
 
 type Sphere struct {
@@ -41,14 +42,27 @@ func (sphere Sphere) TestRay(ray core.Ray, params core.Interval) optional.Option
 }
 
 func (sphere Sphere) evaluateHit(ray core.Ray, hitParam core.Real) Hit {
-	hitPoint := ray.Eval(hitParam)
 	return Hit{
-		Param:  hitParam,
-		Point:  hitPoint,
-		Normal: hitPoint.Sub(sphere.center).Div(sphere.radius),
+		Param:        hitParam,
+		SurfacePoint: sphere.surfacePoint(ray.Eval(hitParam)),
 	}
 }
 
+func (sphere Sphere) surfacePoint(point core.Vec3) core.SurfacePoint {
+	normal := point.Sub(sphere.center).Div(sphere.radius)
+	// The tangent follows the longitude, it's undefined at the poles
+	tangent, bitangent := core.TangentFrame(normal, core.NewVec3(normal.Z(), 0, -normal.X()), core.NewVec3(0, 1, 0))
+	return core.SurfacePoint{Point: point, Normal: normal, UV: sphericalUV(normal), Tangent: tangent, Bitangent: bitangent}
+}
+
+// Maps the unit normal to longitude U and latitude V. U grows counterclockwise around
+// the Y axis starting from -X, V grows from the bottom pole to the top one.
+func sphericalUV(normal core.Vec3) core.Vec2 {
+	theta := core.Acos(core.Max(-1, core.Min(1, -normal.Y())))
+	phi := core.Atan2(-normal.Z(), normal.X()) + core.Pi
+	return core.NewVec2(phi/(2*core.Pi), theta/core.Pi)
+}
+
 func (sphere Sphere) InContactWith(other Sphere) bool {
 	distance := sphere.center.Sub(other.center).Len()
 	return distance <= sphere.radius+other.radius
@@ -58,3 +72,73 @@ func (sphere Sphere) BoundingBox() core.Box {
 	centerToCorner := core.NewVec3(sphere.radius, sphere.radius, sphere.radius)
 	return core.NewBox(sphere.center.Sub(centerToCorner), sphere.center.Add(centerToCorner))
 }
+
+// Sample picks a direction within the cone that the sphere subtends from the origin,
+// so that every sample lies on the visible side of the sphere.
+// https://www.pbr-book.org/3ed-2018/Light_Transport_I_Surface_Reflection/Sampling_Light_Sources#SamplingSpheres
+func (sphere Sphere) Sample(origin core.Vec3, randomizer random.RandomGenerator) SurfaceSample {
+	originToCenter := sphere.center.Sub(origin)
+	distanceSquared := originToCenter.LenSqr()
+	if distanceSquared <= sphere.radius*sphere.radius {
+		return sphere.sampleArea(origin, randomizer)
+	}
+
+	distance := core.Sqrt(distanceSquared)
+	axis := originToCenter.Div(distance)
+	oneMinusCosThetaMax := sphere.coneAngle(distanceSquared)
+	cosTheta := 1 - randomizer.Real()*oneMinusCosThetaMax
+	sinTheta := core.Sqrt(core.Max(0, 1-cosTheta*cosTheta))
+	phi := 2 * core.Pi * randomizer.Real()
+
+	tangent, bitangent := core.OrthonormalBasis(axis)
+	direction := axis.Mul(cosTheta).
+		Add(tangent.Mul(sinTheta * core.Cos(phi))).
+		Add(bitangent.Mul(sinTheta * core.Sin(phi)))
+
+	// Closest intersection of the sampled direction with the sphere, the discriminant
+	// is clamped since directions at the edge of the cone are tangent to the sphere
+	projection := direction.Dot(originToCenter)
+	discriminant := core.Max(0, sphere.radius*sphere.radius-(distanceSquared-projection*projection))
+	point := origin.Add(direction.Mul(projection - core.Sqrt(discriminant)))
+
+	return SurfaceSample{
+		SurfacePoint: sphere.surfacePoint(point),
+		PDF:          uniformConePDF(oneMinusCosThetaMax),
+	}
+}
+
+func (sphere Sphere) PDF(origin core.Vec3, hit Hit) core.Real {
+	distanceSquared := sphere.center.Sub(origin).LenSqr()
+	if distanceSquared <= sphere.radius*sphere.radius {
+		return areaToSolidAnglePDF(1/sphere.area(), origin, hit.Point, hit.Normal)
+	}
+	return uniformConePDF(sphere.coneAngle(distanceSquared))
+}
+
+// Origins inside the sphere see all of it, so points are sampled uniformly over the surface.
+func (sphere Sphere) sampleArea(origin core.Vec3, randomizer random.RandomGenerator) SurfaceSample {
+	cosTheta := 1 - 2*randomizer.Real()
+	sinTheta := core.Sqrt(core.Max(0, 1-cosTheta*cosTheta))
+	phi := 2 * core.Pi * randomizer.Real()
+	normal := core.NewVec3(sinTheta*core.Cos(phi), sinTheta*core.Sin(phi), cosTheta)
+	point := sphere.center.Add(normal.Mul(sphere.radius))
+
+	return SurfaceSample{
+		SurfacePoint: sphere.surfacePoint(point),
+		PDF:          areaToSolidAnglePDF(1/sphere.area(), origin, point, normal),
+	}
+}
+
+// Returns 1 - cos of the half-angle of the cone, computed without cancellation for distant spheres.
+func (sphere Sphere) coneAngle(distanceSquared core.Real) core.Real {
+	sinSquared := sphere.radius * sphere.radius / distanceSquared
+	return sinSquared / (1 + core.Sqrt(core.Max(0, 1-sinSquared)))
+}
+
+func (sphere Sphere) area() core.Real {
+	return 4 * core.Pi * sphere.radius * sphere.radius
+}
+
+func uniformConePDF(oneMinusCosThetaMax core.Real) core.Real {
+	return 1 / (2 * core.Pi * oneMinusCosThetaMax)
+}
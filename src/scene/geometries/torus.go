@@ -0,0 +1,95 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+// Torus is a tube of the minor radius swept around the axis along a circle of the major radius.
+// U is the angle around the axis and V the angle around the tube, starting from its outer side.
+type Torus struct {
+	center       core.Vec3
+	frame        axisFrame
+	majorRadius  core.Real
+	minorRadius  core.Real
+	boundsRadius core.Real
+}
+
+func NewTorus(center, axis core.Vec3, majorRadius, minorRadius core.Real) Torus {
+	if axis.LenSqr() == 0 {
+		panic(fmt.Errorf("new torus: axis must not be zero"))
+	}
+	if minorRadius <= 0 || majorRadius <= 0 {
+		panic(fmt.Errorf("new torus: radii must be positive, got %v and %v", majorRadius, minorRadius))
+	}
+	return Torus{
+		center:       center,
+		frame:        newAxisFrame(axis),
+		majorRadius:  majorRadius,
+		minorRadius:  minorRadius,
+		boundsRadius: majorRadius + minorRadius,
+	}
+}
+
+// Points on the torus satisfy (|p|^2 + R^2 - r^2)^2 = 4 R^2 |radial(p)|^2, which is quartic along the ray.
+// The ray is normalized and moved next to the torus first, so that the coefficients stay small.
+// http://www.cosinekitty.com/raytrace/chapter13_torus.html
+func (t Torus) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	directionLength := ray.Direction().Len()
+	direction := ray.Direction().Div(directionLength)
+
+	// Start at the closest point of the ray line to the torus center,
+	// lines that don't come close enough miss the torus
+	start := t.center.Sub(ray.Origin()).Dot(direction)
+	origin := ray.Origin().Add(direction.Mul(start)).Sub(t.center)
+	if origin.LenSqr() > t.boundsRadius*t.boundsRadius {
+		return optional.Empty[Hit]()
+	}
+
+	R2 := t.majorRadius * t.majorRadius
+	g := origin.LenSqr() + R2 - t.minorRadius*t.minorRadius
+	f := origin.Dot(direction)
+	radialOrigin, radialDirection := t.frame.radial(origin), t.frame.radial(direction)
+
+	solution := core.SolveQuarticEquation(1, 4*f,
+		4*f*f+2*g-4*R2*radialDirection.LenSqr(),
+		4*f*g-8*R2*radialOrigin.Dot(radialDirection),
+		g*g-4*R2*radialOrigin.LenSqr())
+
+	for _, root := range solution.Roots[:solution.Count] {
+		hitParam := (start + root) / directionLength
+		if params.Contains(hitParam) {
+			return optional.Of(Hit{Param: hitParam, SurfacePoint: t.surfacePoint(ray.Eval(hitParam))})
+		}
+	}
+	return optional.Empty[Hit]()
+}
+
+func (t Torus) surfacePoint(point core.Vec3) core.SurfacePoint {
+	offset := point.Sub(t.center)
+	radial := t.frame.radial(offset)
+	tubeCenter := radial.Normalize().Mul(t.majorRadius)
+	normal := offset.Sub(tubeCenter).Normalize()
+
+	height := t.frame.height(offset)
+	tubeAngle := core.Atan2(height, radial.Len()-t.majorRadius)
+	tubeAngle = core.IfElse(tubeAngle < 0, tubeAngle+2*core.Pi, tubeAngle)
+	aroundTube := t.frame.angular(radial).Cross(normal)
+
+	tangent, bitangent := core.TangentFrame(normal, t.frame.angular(radial), aroundTube)
+	return core.SurfacePoint{
+		Point:     point,
+		Normal:    normal,
+		UV:        core.NewVec2(t.frame.angle(radial)/(2*core.Pi), tubeAngle/(2*core.Pi)),
+		Tangent:   tangent,
+		Bitangent: bitangent,
+	}
+}
+
+func (t Torus) BoundingBox() core.Box {
+	ring := t.frame.circleExtent(t.majorRadius)
+	extent := ring.Add(core.NewVec3(t.minorRadius, t.minorRadius, t.minorRadius))
+	return core.NewBox(t.center.Sub(extent), t.center.Add(extent))
+}
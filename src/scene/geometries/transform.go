@@ -0,0 +1,73 @@
+package geometries
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+)
+
+// Transform places a hittable in the world with an affine transformation.
+// The wrapped hittable isn't copied, so a mesh can be instanced many times
+// at the cost of a few matrices per instance.
+type Transform struct {
+	hittable      Hittable
+	toWorld       core.Mat4
+	toObject      core.Mat4
+	normalToWorld core.Mat4
+	boundingBox   core.Box
+}
+
+func NewTransform(hittable Hittable, toWorld core.Mat4) Transform {
+	if !toWorld.Invertible() {
+		panic(fmt.Errorf("new transform: matrix is not invertible: %v", toWorld))
+	}
+
+	transform := newTransform(hittable, toWorld, toWorld.Inverse())
+	transform.boundingBox = toWorld.TransformBox(hittable.BoundingBox())
+	return transform
+}
+
+// Without the bounding box, which isn't needed to test rays.
+func newTransform(hittable Hittable, toWorld, toObject core.Mat4) Transform {
+	return Transform{
+		hittable:      hittable,
+		toWorld:       toWorld,
+		toObject:      toObject,
+		normalToWorld: toObject.Transpose(),
+	}
+}
+
+func NewTranslation(hittable Hittable, offset core.Vec3) Transform {
+	return NewTransform(hittable, core.Translation(offset))
+}
+
+func NewRotation(hittable Hittable, angle core.Real, axis core.Vec3) Transform {
+	return NewTransform(hittable, core.Rotation(angle, axis))
+}
+
+func NewScaling(hittable Hittable, factors core.Vec3) Transform {
+	return NewTransform(hittable, core.Scaling(factors))
+}
+
+// The ray direction is transformed without normalization, so that
+// the hit parameter is the same in the object and world spaces.
+func (t Transform) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
+	objectRay := core.NewRayAtTime(t.toObject.TransformPoint(ray.Origin()), t.toObject.TransformDirection(ray.Direction()), ray.Time())
+
+	optionalHit := t.hittable.TestRay(objectRay, params)
+	if optionalHit.Empty() {
+		return optionalHit
+	}
+
+	hit := optionalHit.Value()
+	hit.Point = ray.Eval(hit.Param)
+	hit.Normal = t.normalToWorld.TransformDirection(hit.Normal).Normalize()
+	hit.Tangent, hit.Bitangent = core.TangentFrame(hit.Normal,
+		t.toWorld.TransformDirection(hit.Tangent), t.toWorld.TransformDirection(hit.Bitangent))
+	return optional.Of(hit)
+}
+
+func (t Transform) BoundingBox() core.Box {
+	return t.boundingBox
+}
@@ -3,20 +3,29 @@ package geometries
 import (
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 )
 
 type Triangle struct {
-	v0, v1, v2 core.Vec3
-	n0, n1, n2 core.Vec3
+	v0, v1, v2    core.Vec3
+	n0, n1, n2    core.Vec3
+	uv0, uv1, uv2 core.Vec2
 }
 
+// Triangles map their vertices to UV coordinates (0, 0), (1, 0) and (0, 1) unless other UVs are set.
 func NewTriangle(v0, v1, v2 core.Vec3) Triangle {
 	norm := core.Normal(v0, v1, v2)
-	return Triangle{v0, v1, v2, norm, norm, norm}
+	return NewTriangleWithNormals(v0, v1, v2, norm, norm, norm)
 }
 
 func NewTriangleWithNormals(v0, v1, v2, n0, n1, n2 core.Vec3) Triangle {
-	return Triangle{v0, v1, v2, n0, n1, n2}
+	return Triangle{v0, v1, v2, n0, n1, n2, core.NewVec2(0, 0), core.NewVec2(1, 0), core.NewVec2(0, 1)}
+}
+
+// WithUVs returns a copy of the triangle with the given per-vertex texture coordinates.
+func (t Triangle) WithUVs(uv0, uv1, uv2 core.Vec2) Triangle {
+	t.uv0, t.uv1, t.uv2 = uv0, uv1, uv2
+	return t
 }
 
 func (t Triangle) BoundingBox() core.Box {
@@ -24,53 +33,150 @@ func (t Triangle) BoundingBox() core.Box {
 		core.Vec3Max(core.Vec3Max(t.v0, t.v1), t.v2))
 }
 
-// https://en.wikipedia.org/wiki/M%C3%B6ller%E2%80%93Trumbore_intersection_algorithm
+// TestRay is watertight: the vertices are moved into a space where the ray starts at the origin
+// and points along Z, so neighbouring triangles evaluate the same edge functions for a shared edge
+// and rays can't slip between them. The triangles are double-sided.
+// https://jcgt.org/published/0002/01/05/paper.pdf
 func (t Triangle) TestRay(ray core.Ray, params core.Interval) optional.Optional[Hit] {
-	edge1 := t.v1.Sub(t.v0)
-	edge2 := t.v2.Sub(t.v0)
-	rayCrossEdge2 := ray.Direction().Cross(edge2)
-	det := edge1.Dot(rayCrossEdge2)
-
-	if rayIsParallelToTriangle(det) {
-		return optional.Empty[Hit]()
+	shear := newRayShear(ray.Direction())
+	a := shear.apply(t.v0.Sub(ray.Origin()))
+	b := shear.apply(t.v1.Sub(ray.Origin()))
+	c := shear.apply(t.v2.Sub(ray.Origin()))
+
+	// Twice the signed areas of the triangles the ray forms with each edge,
+	// the weights of the opposite vertices
+	u := c.X()*b.Y() - c.Y()*b.X()
+	v := a.X()*c.Y() - a.Y()*c.X()
+	w := b.X()*a.Y() - b.Y()*a.X()
+	if u == 0 || v == 0 || w == 0 {
+		u, v, w = edgeFunctions64(a, b, c)
 	}
 
-	invDet := 1.0 / det
-	originToVertex0 := ray.Origin().Sub(t.v0)
-
-	u := invDet * originToVertex0.Dot(rayCrossEdge2)
-	if !core.NewInterval(0, 1).Contains(u) {
+	if (u < 0 || v < 0 || w < 0) && (u > 0 || v > 0 || w > 0) {
 		return optional.Empty[Hit]()
 	}
-
-	q := originToVertex0.Cross(edge1)
-	v := invDet * ray.Direction().Dot(q)
-	if !core.NewInterval(0, 1-u).Contains(v) {
+	det := u + v + w
+	if det == 0 {
+		// The ray is in the plane of the triangle
 		return optional.Empty[Hit]()
 	}
 
-	rayParam := invDet * edge2.Dot(q)
+	rayParam := (u*a.Z() + v*b.Z() + w*c.Z()) / det
 	if !params.Contains(rayParam) {
 		return optional.Empty[Hit]()
 	}
 
-	return optional.Of(t.evaluateHit(ray, rayParam, u, v))
+	return optional.Of(t.evaluateHit(ray, rayParam, v/det, w/det))
 }
 
-func rayIsParallelToTriangle(det core.Real) bool {
-	return core.Abs(det) < core.Tolerance
+// rayShear maps the ray direction to the Z axis, the largest direction component becomes Z.
+type rayShear struct {
+	kx, ky, kz int
+	sx, sy, sz core.Real
 }
 
-func (t Triangle) evaluateHit(ray core.Ray, hitParam, u, v core.Real) Hit {
-	hitPoint := ray.Eval(hitParam)
+func newRayShear(direction core.Vec3) rayShear {
+	absX, absY, absZ := core.Abs(direction.X()), core.Abs(direction.Y()), core.Abs(direction.Z())
+	shear := rayShear{kx: 1, ky: 2, kz: 0}
+	if absY >= absX && absY >= absZ {
+		shear = rayShear{kx: 2, ky: 0, kz: 1}
+	} else if absZ >= absX {
+		shear = rayShear{kx: 0, ky: 1, kz: 2}
+	}
 
+	permuted := direction.Permute(shear.kx, shear.ky, shear.kz)
+	shear.sx = permuted.X() / permuted.Z()
+	shear.sy = permuted.Y() / permuted.Z()
+	shear.sz = 1 / permuted.Z()
+	return shear
+}
+
+func (s rayShear) apply(vertex core.Vec3) core.Vec3 {
+	permuted := vertex.Permute(s.kx, s.ky, s.kz)
+	return core.NewVec3(permuted.X()-s.sx*permuted.Z(), permuted.Y()-s.sy*permuted.Z(), s.sz*permuted.Z())
+}
+
+// On edges and vertices the edge functions are recomputed in double precision to get their signs right.
+func edgeFunctions64(a, b, c core.Vec3) (core.Real, core.Real, core.Real) {
+	ax, ay := float64(a.X()), float64(a.Y())
+	bx, by := float64(b.X()), float64(b.Y())
+	cx, cy := float64(c.X()), float64(c.Y())
+	return core.Real(cx*by - cy*bx), core.Real(ax*cy - ay*cx), core.Real(bx*ay - by*ax)
+}
+
+func (t Triangle) evaluateHit(ray core.Ray, hitParam, u, v core.Real) Hit {
+	normal := t.normalGouraud(u, v)
+	tangent, bitangent := t.tangentFrame(normal)
 	return Hit{
-		Param:  hitParam,
-		Point:  hitPoint,
-		Normal: t.normalGouraud(u, v),
+		Param: hitParam,
+		SurfacePoint: core.SurfacePoint{
+			Point:     ray.Eval(hitParam),
+			Normal:    normal,
+			UV:        t.interpolateUV(u, v),
+			Tangent:   tangent,
+			Bitangent: bitangent,
+		},
 	}
 }
 
+func (t Triangle) interpolateUV(u, v core.Real) core.Vec2 {
+	return t.uv0.Mul(1.0 - u - v).Add(t.uv1.Mul(u)).Add(t.uv2.Mul(v))
+}
+
+// The derivatives of the position with respect to U and V, orthonormalized against the normal.
+// https://terathon.com/blog/tangent-space.html
+func (t Triangle) tangentFrame(normal core.Vec3) (core.Vec3, core.Vec3) {
+	edge1, edge2 := t.v1.Sub(t.v0), t.v2.Sub(t.v0)
+	deltaUV1, deltaUV2 := t.uv1.Sub(t.uv0), t.uv2.Sub(t.uv0)
+	det := deltaUV1.X()*deltaUV2.Y() - deltaUV2.X()*deltaUV1.Y()
+	if det == 0 {
+		return core.OrthonormalBasis(normal)
+	}
+
+	tangent := edge1.Mul(deltaUV2.Y()).Sub(edge2.Mul(deltaUV1.Y())).Div(det)
+	bitangent := edge2.Mul(deltaUV1.X()).Sub(edge1.Mul(deltaUV2.X())).Div(det)
+	return core.TangentFrame(normal, tangent, bitangent)
+}
+
 func (t Triangle) normalGouraud(u, v core.Real) core.Vec3 {
 	return t.n0.Mul(1.0 - u - v).Add(t.n1.Mul(u)).Add(t.n2.Mul(v)).Normalize()
 }
+
+// Sample picks a point uniformly over the triangle area.
+// https://www.pbr-book.org/3ed-2018/Monte_Carlo_Integration/2D_Sampling_with_Multidimensional_Transformations#SamplingaTriangle
+func (t Triangle) Sample(origin core.Vec3, randomizer random.RandomGenerator) SurfaceSample {
+	surface := t.sampleSurface(randomizer)
+	return SurfaceSample{
+		SurfacePoint: surface,
+		PDF:          areaToSolidAnglePDF(1/t.area(), origin, surface.Point, surface.Normal),
+	}
+}
+
+func (t Triangle) PDF(origin core.Vec3, hit Hit) core.Real {
+	return areaToSolidAnglePDF(1/t.area(), origin, hit.Point, t.geometricNormal())
+}
+
+// The normal of sampled points is the geometric one, since light leaves the actual surface.
+func (t Triangle) sampleSurface(randomizer random.RandomGenerator) core.SurfacePoint {
+	sqrtU := core.Sqrt(randomizer.Real())
+	b0 := 1 - sqrtU
+	b1 := randomizer.Real() * sqrtU
+	b2 := 1 - b0 - b1
+	normal := t.geometricNormal()
+	tangent, bitangent := t.tangentFrame(normal)
+	return core.SurfacePoint{
+		Point:     t.v0.Mul(b0).Add(t.v1.Mul(b1)).Add(t.v2.Mul(b2)),
+		Normal:    normal,
+		UV:        t.interpolateUV(b1, b2),
+		Tangent:   tangent,
+		Bitangent: bitangent,
+	}
+}
+
+func (t Triangle) geometricNormal() core.Vec3 {
+	return core.Normal(t.v0, t.v1, t.v2)
+}
+
+func (t Triangle) area() core.Real {
+	return t.v1.Sub(t.v0).Cross(t.v2.Sub(t.v0)).Len() / 2
+}
@@ -0,0 +1,90 @@
+package importers
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+)
+
+// DENSITY_GRID_MAGIC starts every density grid file.
+const DENSITY_GRID_MAGIC = "GRID"
+
+// Guards against allocating huge grids for corrupted headers.
+const maxDensityGridVoxels = 1 << 28
+
+// The header of a density grid file, all values are little-endian.
+// The densities follow as float32 values, X changes fastest, then Y, then Z.
+type densityGridHeader struct {
+	Magic      [4]byte
+	Resolution [3]uint32
+	BoundsMin  [3]float32
+	BoundsMax  [3]float32
+}
+
+func LoadDensityGridFile(filename string) (*geometries.DensityGrid, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("load density grid: %w", err)
+	}
+	defer file.Close()
+
+	grid, err := ReadDensityGrid(file)
+	if err != nil {
+		return nil, fmt.Errorf("load density grid %s: %w", filename, err)
+	}
+	return grid, nil
+}
+
+// ReadDensityGrid parses a raw voxel grid: a header with the magic "GRID", the resolution
+// as three uint32 and the bounds as six float32, minimum then maximum corner, followed by
+// the densities as float32 values. All values are little-endian.
+func ReadDensityGrid(reader io.Reader) (*geometries.DensityGrid, error) {
+	reader = bufio.NewReader(reader)
+	var header densityGridHeader
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("read density grid header: %w", err)
+	}
+	if string(header.Magic[:]) != DENSITY_GRID_MAGIC {
+		return nil, fmt.Errorf("read density grid: invalid magic %q", header.Magic[:])
+	}
+
+	resolution := [3]int{}
+	voxelCount := 1
+	for axis, size := range header.Resolution {
+		if size == 0 || size > maxDensityGridVoxels {
+			return nil, fmt.Errorf("read density grid: invalid resolution %v", header.Resolution)
+		}
+		resolution[axis] = int(size)
+		voxelCount *= int(size)
+		if voxelCount > maxDensityGridVoxels {
+			return nil, fmt.Errorf("read density grid: resolution %v is too large", header.Resolution)
+		}
+	}
+
+	for axis := range header.BoundsMin {
+		if !(header.BoundsMin[axis] < header.BoundsMax[axis]) {
+			return nil, fmt.Errorf("read density grid: invalid bounds %v, %v", header.BoundsMin, header.BoundsMax)
+		}
+	}
+	bounds := core.NewBox(
+		core.NewVec3(core.Real(header.BoundsMin[0]), core.Real(header.BoundsMin[1]), core.Real(header.BoundsMin[2])),
+		core.NewVec3(core.Real(header.BoundsMax[0]), core.Real(header.BoundsMax[1]), core.Real(header.BoundsMax[2])))
+
+	values := make([]float32, voxelCount)
+	if err := binary.Read(reader, binary.LittleEndian, values); err != nil {
+		return nil, fmt.Errorf("read density grid densities: %w", err)
+	}
+	densities := make([]core.Real, voxelCount)
+	for i, value := range values {
+		if value < 0 {
+			return nil, fmt.Errorf("read density grid: negative density %f at voxel %d", value, i)
+		}
+		densities[i] = core.Real(value)
+	}
+	return geometries.NewDensityGrid(resolution, bounds, densities), nil
+}
@@ -0,0 +1,54 @@
+package importers
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/png"
+	"io"
+	"os"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+)
+
+func LoadHeightfieldFile(filename string, bounds core.Box) (*geometries.Heightfield, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("load heightfield: %w", err)
+	}
+	defer file.Close()
+
+	field, err := ReadHeightfield(file, bounds)
+	if err != nil {
+		return nil, fmt.Errorf("load heightfield %s: %w", filename, err)
+	}
+	return field, nil
+}
+
+// ReadHeightfield decodes a grayscale image, e.g. a 16-bit PNG, into a heightfield spanning the bounds.
+// Image columns go along X and rows along Z, black is the bottom of the bounds and white the top.
+// Colored images are converted to their luminance.
+func ReadHeightfield(reader io.Reader, bounds core.Box) (*geometries.Heightfield, error) {
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read heightfield: %w", err)
+	}
+
+	size := img.Bounds().Size()
+	if size.X < 2 || size.Y < 2 {
+		return nil, fmt.Errorf("read heightfield: image must be at least 2x2 pixels, got %dx%d", size.X, size.Y)
+	}
+	if !(bounds.Min().X() < bounds.Max().X()) || !(bounds.Min().Z() < bounds.Max().Z()) || bounds.Min().Y() > bounds.Max().Y() {
+		return nil, fmt.Errorf("read heightfield: invalid bounds %v", bounds)
+	}
+
+	heights := make([]core.Real, 0, size.X*size.Y)
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			gray := color.Gray16Model.Convert(img.At(x, y)).(color.Gray16)
+			heights = append(heights, core.Real(gray.Y)/0xffff)
+		}
+	}
+	return geometries.NewHeightfield([2]int{size.X, size.Y}, bounds, heights), nil
+}
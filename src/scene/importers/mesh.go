@@ -0,0 +1,60 @@
+package importers
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+)
+
+// indexedTriangles is the common intermediate representation of mesh file formats:
+// a vertex list and triangles referencing it by index.
+type indexedTriangles struct {
+	positions []core.Vec3
+	normals   []core.Vec3 // nil if the file has no vertex normals
+	faces     [][3]int
+}
+
+// addPolygon triangulates a polygon as a fan around its first vertex.
+func (t *indexedTriangles) addPolygon(indices []int) error {
+	if len(indices) < 3 {
+		return fmt.Errorf("polygon with %d vertices", len(indices))
+	}
+	for _, index := range indices {
+		if index < 0 || index >= len(t.positions) {
+			return fmt.Errorf("vertex index %d out of range [0, %d)", index, len(t.positions))
+		}
+	}
+
+	for i := 1; i+1 < len(indices); i++ {
+		t.faces = append(t.faces, [3]int{indices[0], indices[i], indices[i+1]})
+	}
+	return nil
+}
+
+// Without normals in the file, the mesh is smooth shaded across all edges.
+func (t *indexedTriangles) buildMesh() (geometries.Mesh, error) {
+	if len(t.faces) == 0 {
+		return geometries.Mesh{}, fmt.Errorf("mesh has no faces")
+	}
+	if !t.hasValidFace() {
+		return geometries.Mesh{}, fmt.Errorf("mesh has only degenerate faces")
+	}
+
+	mesh := geometries.NewIndexedMesh(t.positions, t.faces)
+	if t.normals != nil {
+		mesh = mesh.WithNormals(t.normals)
+	} else {
+		mesh = mesh.SmoothNormals(180, geometries.AreaWeighting)
+	}
+	return mesh.Build(), nil
+}
+
+func (t *indexedTriangles) hasValidFace() bool {
+	for _, face := range t.faces {
+		if !isDegenerate(t.positions[face[0]], t.positions[face[1]], t.positions[face[2]]) {
+			return true
+		}
+	}
+	return false
+}
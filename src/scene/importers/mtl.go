@@ -0,0 +1,327 @@
+package importers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+)
+
+// MTLMaterial holds the subset of Wavefront MTL parameters the ray tracer can render,
+// including the physically based extension.
+// http://exocortex.com/blog/extending_wavefront_mtl_to_support_pbr
+type MTLMaterial struct {
+	Diffuse         color.Color // Kd
+	Specular        color.Color // Ks
+	Emissive        color.Color // Ke
+	Transmission    color.Color // Tf
+	RefractionIndex core.Real   // Ni
+	Shininess       core.Real   // Ns
+	Dissolve        core.Real   // d, or 1 - Tr
+	Illumination    int         // illum
+
+	Roughness          core.Real // Pr
+	Metallic           core.Real // Pm
+	Sheen              core.Real // Ps
+	Clearcoat          core.Real // Pc
+	ClearcoatRoughness core.Real // Pcr
+	// PBR is set by any of the physically based statements, the material then maps to materials.Principled.
+	PBR bool
+
+	// File names of the texture maps, relative to the material library
+	DiffuseMap   string // map_Kd
+	RoughnessMap string // map_Pr
+	MetallicMap  string // map_Pm
+	SheenMap     string // map_Ps
+	NormalMap    string // norm
+}
+
+// TextureLoader opens a texture map of a material. Color maps are gamma corrected,
+// data maps like roughness or normal maps are linear.
+type TextureLoader func(filename string, linear bool) (textures.Texture, error)
+
+// NewMTLMaterial returns a material with the default MTL parameters.
+func NewMTLMaterial() MTLMaterial {
+	return MTLMaterial{
+		Diffuse:         color.GrayLight,
+		Specular:        color.Black,
+		Emissive:        color.Black,
+		Transmission:    color.White,
+		RefractionIndex: 1,
+		Shininess:       0,
+		Dissolve:        1,
+		Illumination:    2,
+
+		Roughness:          0.5,
+		ClearcoatRoughness: 0.1,
+	}
+}
+
+// ReadMTL parses a Wavefront MTL material library and returns its materials by name.
+func ReadMTL(reader io.Reader) (map[string]MTLMaterial, error) {
+	materials := map[string]MTLMaterial{}
+	currentName := ""
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+
+		keyword, args := fields[0], fields[1:]
+		if keyword == "newmtl" {
+			if len(args) == 0 {
+				return nil, fmt.Errorf("read mtl: line %d: newmtl: missing material name", lineNumber)
+			}
+			currentName = args[0]
+			materials[currentName] = NewMTLMaterial()
+			continue
+		}
+
+		current, ok := materials[currentName]
+		if !ok {
+			return nil, fmt.Errorf("read mtl: line %d: %s before newmtl", lineNumber, keyword)
+		}
+		if err := current.readStatement(keyword, args); err != nil {
+			return nil, fmt.Errorf("read mtl: line %d: %w", lineNumber, err)
+		}
+		materials[currentName] = current
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read mtl: %w", err)
+	}
+	return materials, nil
+}
+
+func (m *MTLMaterial) readStatement(keyword string, args []string) error {
+	var err error
+	switch keyword {
+	case "Kd":
+		m.Diffuse, err = parseColor(args)
+	case "Ks":
+		m.Specular, err = parseColor(args)
+	case "Ke":
+		m.Emissive, err = parseColor(args)
+	case "Tf":
+		m.Transmission, err = parseColor(args)
+	case "Ni":
+		m.RefractionIndex, err = parseScalar(args)
+	case "Ns":
+		m.Shininess, err = parseScalar(args)
+	case "d":
+		m.Dissolve, err = parseScalar(args)
+	case "Tr":
+		var transparency core.Real
+		transparency, err = parseScalar(args)
+		m.Dissolve = 1 - transparency
+	case "Pr":
+		m.Roughness, err = parseScalar(args)
+		m.PBR = true
+	case "Pm":
+		m.Metallic, err = parseScalar(args)
+		m.PBR = true
+	case "Ps":
+		m.Sheen, err = parseScalar(args)
+		m.PBR = true
+	case "Pc":
+		m.Clearcoat, err = parseScalar(args)
+		m.PBR = true
+	case "Pcr":
+		m.ClearcoatRoughness, err = parseScalar(args)
+		m.PBR = true
+	case "map_Kd":
+		m.DiffuseMap, err = parseMapFilename(args)
+	case "map_Pr":
+		m.RoughnessMap, err = parseMapFilename(args)
+		m.PBR = true
+	case "map_Pm":
+		m.MetallicMap, err = parseMapFilename(args)
+		m.PBR = true
+	case "map_Ps":
+		m.SheenMap, err = parseMapFilename(args)
+		m.PBR = true
+	case "norm":
+		m.NormalMap, err = parseMapFilename(args)
+	case "illum":
+		if len(args) == 0 {
+			return fmt.Errorf("illum: missing value")
+		}
+		m.Illumination, err = strconv.Atoi(args[0])
+	default:
+		// Ambient color, other texture maps and other statements are not supported.
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyword, err)
+	}
+	return nil
+}
+
+// ToMaterial picks the ray tracer material that best matches the MTL parameters, ignoring texture maps.
+// Emission takes precedence over transparency, which takes precedence over reflection.
+// Materials with physically based parameters become principled materials.
+func (m MTLMaterial) ToMaterial(randomizer random.RandomGenerator) materials.Material {
+	material, _ := m.ToTexturedMaterial(randomizer, nil)
+	return material
+}
+
+// ToTexturedMaterial is like ToMaterial, but also applies the texture maps opened with the loader.
+// A nil loader ignores the texture maps.
+func (m MTLMaterial) ToTexturedMaterial(randomizer random.RandomGenerator, loadTexture TextureLoader) (materials.Material, error) {
+	if m.isEmissive() {
+		return materials.NewDiffusiveLight(m.Emissive, 1), nil
+	}
+
+	maps, err := m.loadMaps(loadTexture)
+	if err != nil {
+		return nil, err
+	}
+
+	var material materials.Material
+	switch {
+	case m.PBR:
+		material = m.toPrincipled(maps, randomizer)
+	case m.isTransparent():
+		material = materials.NewTransparent(m.refractionIndex(), m.Transmission, randomizer)
+	case m.isReflective():
+		material = materials.NewReflectiveFuzzy(m.Specular, m.fuzziness(), randomizer)
+	default:
+		material = materials.NewDiffusiveTextured(maps.diffuse, randomizer)
+	}
+
+	if maps.normal != nil {
+		material = materials.NewNormalMapped(material, maps.normal)
+	}
+	return material, nil
+}
+
+// mtlTextures holds the parameters of a material that may come from texture maps.
+type mtlTextures struct {
+	diffuse, roughness, metallic, sheen textures.Texture
+	normal                              textures.Texture // nil without a normal map
+}
+
+func (m MTLMaterial) loadMaps(loadTexture TextureLoader) (mtlTextures, error) {
+	maps := mtlTextures{
+		diffuse:   textures.NewConstant(m.Diffuse),
+		roughness: textures.NewScalar(m.Roughness),
+		metallic:  textures.NewScalar(m.Metallic),
+		sheen:     textures.NewScalar(m.Sheen),
+	}
+	if loadTexture == nil {
+		return maps, nil
+	}
+
+	for _, textureMap := range []struct {
+		filename string
+		linear   bool
+		texture  *textures.Texture
+	}{
+		{m.DiffuseMap, false, &maps.diffuse},
+		{m.RoughnessMap, true, &maps.roughness},
+		{m.MetallicMap, true, &maps.metallic},
+		{m.SheenMap, true, &maps.sheen},
+		{m.NormalMap, true, &maps.normal},
+	} {
+		if textureMap.filename == "" {
+			continue
+		}
+		texture, err := loadTexture(textureMap.filename, textureMap.linear)
+		if err != nil {
+			return mtlTextures{}, fmt.Errorf("texture map %s: %w", textureMap.filename, err)
+		}
+		*textureMap.texture = texture
+	}
+	return maps, nil
+}
+
+// Dissolved PBR materials become transmissive, Tf doesn't apply to them.
+func (m MTLMaterial) toPrincipled(maps mtlTextures, randomizer random.RandomGenerator) materials.Principled {
+	settings := []materials.PrincipledSetting{
+		materials.BaseColor(maps.diffuse),
+		materials.Roughness(maps.roughness),
+		materials.Metallic(maps.metallic),
+		materials.Sheen(maps.sheen),
+		materials.Clearcoat(textures.NewScalar(m.Clearcoat)),
+		materials.ClearcoatRoughness(textures.NewScalar(m.ClearcoatRoughness)),
+		materials.Transmission(textures.NewScalar(1 - m.Dissolve)),
+	}
+	if m.RefractionIndex > 1 {
+		settings = append(settings, materials.IOR(m.RefractionIndex))
+	}
+	return materials.NewPrincipled(randomizer, settings...)
+}
+
+func (m MTLMaterial) refractionIndex() core.Real {
+	return core.IfElse(m.RefractionIndex < 1, 1, m.RefractionIndex)
+}
+
+func (m MTLMaterial) isEmissive() bool {
+	return m.Emissive != color.Black
+}
+
+func (m MTLMaterial) isTransparent() bool {
+	switch m.Illumination {
+	case 4, 6, 7, 9:
+		return true
+	}
+	return m.Dissolve < 1
+}
+
+func (m MTLMaterial) isReflective() bool {
+	if m.Specular == color.Black {
+		return false
+	}
+	switch m.Illumination {
+	case 3, 5, 8:
+		return true
+	}
+	return m.Diffuse == color.Black
+}
+
+// Maps the Phong exponent to the fuzziness of a reflective material:
+// Ns = 0 gives a fully fuzzy surface, Ns = 1000 gives almost a perfect mirror.
+func (m MTLMaterial) fuzziness() core.Real {
+	fuzziness := core.Sqrt(2 / (core.Max(m.Shininess, 0) + 2))
+	return core.Min(fuzziness, 1)
+}
+
+func parseColor(args []string) (color.Color, error) {
+	if len(args) == 1 {
+		value, err := parseReal(args[0])
+		return color.New(value, value, value), err
+	}
+
+	vec, err := parseVec3(args)
+	if err != nil {
+		return color.Black, err
+	}
+	return color.FromVec3(vec), nil
+}
+
+// Texture map statements may have options before the file name, those are ignored.
+func parseMapFilename(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("missing file name")
+	}
+	return args[len(args)-1], nil
+}
+
+func parseScalar(args []string) (core.Real, error) {
+	if len(args) == 0 {
+		return 0, fmt.Errorf("missing value")
+	}
+	return parseReal(args[0])
+}
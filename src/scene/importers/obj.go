@@ -0,0 +1,381 @@
+package importers
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+)
+
+// LoadOBJFile reads a Wavefront OBJ file from disk.
+// Material libraries referenced by the file are resolved relative to its directory.
+func LoadOBJFile(filename string, randomizer random.RandomGenerator) ([]scene.Object, error) {
+	return LoadOBJ(os.DirFS(filepath.Dir(filename)), filepath.Base(filename), randomizer)
+}
+
+// LoadOBJ reads a Wavefront OBJ file from a file system and converts it into scene objects.
+// Every group (g) or object (o) becomes a separate scene object. A group using several
+// materials is split into one scene object per material.
+func LoadOBJ(fileSystem fs.FS, filename string, randomizer random.RandomGenerator) ([]scene.Object, error) {
+	file, err := fileSystem.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("load obj: %w", err)
+	}
+	defer file.Close()
+
+	loader := newObjLoader(fileSystem, path.Dir(filename), randomizer)
+	if err := loader.read(file); err != nil {
+		return nil, fmt.Errorf("load obj %s: %w", filename, err)
+	}
+	return loader.buildObjects()
+}
+
+type objFaceVertex struct {
+	position int
+	uv       int // -1 if the face vertex has no texture coordinates
+	normal   int // -1 if the face vertex has no normal
+}
+
+type objGroup struct {
+	name      string
+	material  string
+	triangles []geometries.Triangle
+}
+
+type objLoader struct {
+	fileSystem fs.FS
+	directory  string
+	randomizer random.RandomGenerator
+
+	positions []core.Vec3
+	uvs       []core.Vec2
+	normals   []core.Vec3
+	materials map[string]MTLMaterial
+	textures  map[objTextureKey]textures.Texture
+
+	groups         []*objGroup
+	groupIndex     map[string]*objGroup
+	activeGroup    string
+	activeMaterial string
+}
+
+func newObjLoader(fileSystem fs.FS, directory string, randomizer random.RandomGenerator) *objLoader {
+	return &objLoader{
+		fileSystem: fileSystem,
+		directory:  directory,
+		randomizer: randomizer,
+		materials:  map[string]MTLMaterial{},
+		textures:   map[objTextureKey]textures.Texture{},
+		groupIndex: map[string]*objGroup{},
+	}
+}
+
+func (l *objLoader) read(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+		if err := l.readLine(fields); err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+	return scanner.Err()
+}
+
+func (l *objLoader) readLine(fields []string) error {
+	keyword, args := fields[0], fields[1:]
+	switch keyword {
+	case "v":
+		vertex, err := parseVec3(args)
+		if err != nil {
+			return fmt.Errorf("vertex: %w", err)
+		}
+		l.positions = append(l.positions, vertex)
+	case "vn":
+		normal, err := parseVec3(args)
+		if err != nil {
+			return fmt.Errorf("normal: %w", err)
+		}
+		if normal.LenSqr() > 0 {
+			normal = normal.Normalize()
+		}
+		l.normals = append(l.normals, normal)
+	case "vt":
+		uv, err := parseVec2(args)
+		if err != nil {
+			return fmt.Errorf("texture coordinates: %w", err)
+		}
+		l.uvs = append(l.uvs, uv)
+	case "f":
+		return l.readFace(args)
+	case "g", "o":
+		l.activeGroup = strings.Join(args, " ")
+	case "usemtl":
+		if len(args) == 0 {
+			return fmt.Errorf("usemtl: missing material name")
+		}
+		l.activeMaterial = args[0]
+	case "mtllib":
+		for _, library := range args {
+			if err := l.readMaterialLibrary(library); err != nil {
+				return err
+			}
+		}
+	default:
+		// Smoothing groups, curves and other statements
+		// don't affect the geometry and are skipped.
+	}
+	return nil
+}
+
+func (l *objLoader) readMaterialLibrary(library string) error {
+	file, err := l.fileSystem.Open(path.Join(l.directory, library))
+	if err != nil {
+		return fmt.Errorf("mtllib: %w", err)
+	}
+	defer file.Close()
+
+	materials, err := ReadMTL(file)
+	if err != nil {
+		return fmt.Errorf("mtllib %s: %w", library, err)
+	}
+	for name, material := range materials {
+		l.materials[name] = material
+	}
+	return nil
+}
+
+func (l *objLoader) readFace(args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("face: expected at least 3 vertices, got %d", len(args))
+	}
+
+	faceVertices := make([]objFaceVertex, 0, len(args))
+	for _, arg := range args {
+		faceVertex, err := l.parseFaceVertex(arg)
+		if err != nil {
+			return fmt.Errorf("face: %w", err)
+		}
+		faceVertices = append(faceVertices, faceVertex)
+	}
+
+	group := l.currentGroup()
+	// Polygons are triangulated as a fan around the first vertex
+	for i := 1; i+1 < len(faceVertices); i++ {
+		triangle, ok := l.makeTriangle(faceVertices[0], faceVertices[i], faceVertices[i+1])
+		if ok {
+			group.triangles = append(group.triangles, triangle)
+		}
+	}
+	return nil
+}
+
+// Face vertices come in the forms v, v/vt, v//vn and v/vt/vn.
+// Indices start at 1, negative indices count from the end of the list.
+func (l *objLoader) parseFaceVertex(arg string) (objFaceVertex, error) {
+	parts := strings.Split(arg, "/")
+	if len(parts) > 3 {
+		return objFaceVertex{}, fmt.Errorf("invalid vertex %q", arg)
+	}
+
+	position, err := resolveIndex(parts[0], len(l.positions))
+	if err != nil {
+		return objFaceVertex{}, fmt.Errorf("vertex %q: %w", arg, err)
+	}
+
+	uv := -1
+	if len(parts) >= 2 && parts[1] != "" {
+		uv, err = resolveIndex(parts[1], len(l.uvs))
+		if err != nil {
+			return objFaceVertex{}, fmt.Errorf("texture coordinates %q: %w", arg, err)
+		}
+	}
+
+	normal := -1
+	if len(parts) == 3 && parts[2] != "" {
+		normal, err = resolveIndex(parts[2], len(l.normals))
+		if err != nil {
+			return objFaceVertex{}, fmt.Errorf("normal %q: %w", arg, err)
+		}
+	}
+
+	return objFaceVertex{position: position, uv: uv, normal: normal}, nil
+}
+
+func resolveIndex(field string, count int) (int, error) {
+	index, err := strconv.Atoi(field)
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 {
+		index += count
+	} else {
+		index--
+	}
+	if index < 0 || index >= count {
+		return 0, fmt.Errorf("index %s out of range [1, %d]", field, count)
+	}
+	return index, nil
+}
+
+func (l *objLoader) makeTriangle(a, b, c objFaceVertex) (geometries.Triangle, bool) {
+	v0, v1, v2 := l.positions[a.position], l.positions[b.position], l.positions[c.position]
+	if isDegenerate(v0, v1, v2) {
+		return geometries.Triangle{}, false
+	}
+
+	triangle := geometries.NewTriangle(v0, v1, v2)
+	if l.hasNormal(a) && l.hasNormal(b) && l.hasNormal(c) {
+		n0, n1, n2 := l.normals[a.normal], l.normals[b.normal], l.normals[c.normal]
+		triangle = geometries.NewTriangleWithNormals(v0, v1, v2, n0, n1, n2)
+	}
+	if a.uv >= 0 && b.uv >= 0 && c.uv >= 0 {
+		triangle = triangle.WithUVs(l.uvs[a.uv], l.uvs[b.uv], l.uvs[c.uv])
+	}
+	return triangle, true
+}
+
+// Zero normals, which some exporters write for degenerate vertices, are treated as missing.
+func (l *objLoader) hasNormal(vertex objFaceVertex) bool {
+	return vertex.normal >= 0 && l.normals[vertex.normal].LenSqr() > 0
+}
+
+func isDegenerate(v0, v1, v2 core.Vec3) bool {
+	return v1.Sub(v0).Cross(v2.Sub(v0)).LenSqr() == 0
+}
+
+func (l *objLoader) currentGroup() *objGroup {
+	key := l.activeGroup + "\x00" + l.activeMaterial
+	group, ok := l.groupIndex[key]
+	if !ok {
+		group = &objGroup{name: l.activeGroup, material: l.activeMaterial}
+		l.groupIndex[key] = group
+		l.groups = append(l.groups, group)
+	}
+	return group
+}
+
+func (l *objLoader) buildObjects() ([]scene.Object, error) {
+	objects := make([]scene.Object, 0, len(l.groups))
+	for _, group := range l.groups {
+		if len(group.triangles) == 0 {
+			continue
+		}
+
+		material, err := l.material(group.material)
+		if err != nil {
+			return nil, fmt.Errorf("load obj: group %q: %w", group.name, err)
+		}
+		objects = append(objects, scene.Object{
+			Hittable: geometries.NewMesh(group.triangles),
+			Material: material,
+		})
+	}
+	return objects, nil
+}
+
+func (l *objLoader) material(name string) (materials.Material, error) {
+	if name == "" {
+		return NewMTLMaterial().ToMaterial(l.randomizer), nil
+	}
+
+	mtlMaterial, ok := l.materials[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown material %q", name)
+	}
+	return mtlMaterial.ToTexturedMaterial(l.randomizer, l.loadTexture)
+}
+
+type objTextureKey struct {
+	filename string
+	linear   bool
+}
+
+// Texture maps are resolved relative to the OBJ file and shared between materials.
+func (l *objLoader) loadTexture(filename string, linear bool) (textures.Texture, error) {
+	key := objTextureKey{filename: filename, linear: linear}
+	if texture, ok := l.textures[key]; ok {
+		return texture, nil
+	}
+
+	file, err := l.fileSystem.Open(path.Join(l.directory, filename))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoded, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+	var texture textures.Texture
+	if linear {
+		texture = textures.NewLinearImage(decoded, textures.WrapRepeat)
+	} else {
+		texture = textures.NewImage(decoded, textures.WrapRepeat)
+	}
+	l.textures[key] = texture
+	return texture, nil
+}
+
+func stripComment(line string) string {
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+func parseReal(field string) (core.Real, error) {
+	value, err := strconv.ParseFloat(field, core.RealBitSize)
+	return core.Real(value), err
+}
+
+// The optional third texture coordinate is ignored.
+func parseVec2(fields []string) (core.Vec2, error) {
+	if len(fields) < 2 {
+		return core.Vec2{}, fmt.Errorf("expected 2 components, got %d", len(fields))
+	}
+
+	u, err := parseReal(fields[0])
+	if err != nil {
+		return core.Vec2{}, err
+	}
+	v, err := parseReal(fields[1])
+	if err != nil {
+		return core.Vec2{}, err
+	}
+	return core.NewVec2(u, v), nil
+}
+
+func parseVec3(fields []string) (core.Vec3, error) {
+	if len(fields) < 3 {
+		return core.Vec3{}, fmt.Errorf("expected 3 components, got %d", len(fields))
+	}
+
+	var components [3]core.Real
+	for i := range components {
+		value, err := parseReal(fields[i])
+		if err != nil {
+			return core.Vec3{}, err
+		}
+		components[i] = value
+	}
+	return core.NewVec3(components[0], components[1], components[2]), nil
+}
@@ -0,0 +1,441 @@
+package importers
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+)
+
+// Limits preallocation, so that a corrupted header can't make us allocate gigabytes upfront.
+const maxPreallocatedElements = 1 << 20
+
+// LoadPLYFile reads a PLY mesh from disk.
+func LoadPLYFile(filename string) (geometries.Mesh, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return geometries.Mesh{}, fmt.Errorf("load ply: %w", err)
+	}
+	defer file.Close()
+
+	return ReadPLY(file)
+}
+
+// ReadPLY parses a PLY mesh in ASCII, binary little endian or binary big endian format.
+// Only the vertex positions, vertex normals and faces are used, other elements are skipped.
+// If the file has no vertex normals or some of them are zero, smooth normals are computed from the faces.
+func ReadPLY(reader io.Reader) (geometries.Mesh, error) {
+	bufferedReader := bufio.NewReader(reader)
+
+	header, err := readPLYHeader(bufferedReader)
+	if err != nil {
+		return geometries.Mesh{}, fmt.Errorf("read ply: header: %w", err)
+	}
+
+	var values plyValueReader
+	switch header.format {
+	case "ascii":
+		values = newPLYASCIIReader(bufferedReader)
+	case "binary_little_endian":
+		values = &plyBinaryReader{reader: bufferedReader, byteOrder: binary.LittleEndian}
+	case "binary_big_endian":
+		values = &plyBinaryReader{reader: bufferedReader, byteOrder: binary.BigEndian}
+	}
+
+	triangles, err := readPLYBody(header, values)
+	if err != nil {
+		return geometries.Mesh{}, fmt.Errorf("read ply: %w", err)
+	}
+
+	mesh, err := triangles.buildMesh()
+	if err != nil {
+		return geometries.Mesh{}, fmt.Errorf("read ply: %w", err)
+	}
+	return mesh, nil
+}
+
+type plyProperty struct {
+	name      string
+	valueType string
+	countType string // empty unless the property is a list
+}
+
+func (p plyProperty) isList() bool {
+	return p.countType != ""
+}
+
+type plyElement struct {
+	name       string
+	count      int
+	properties []plyProperty
+}
+
+func (e plyElement) propertyIndex(names ...string) int {
+	for i, property := range e.properties {
+		for _, name := range names {
+			if property.name == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+type plyHeader struct {
+	format   string
+	elements []plyElement
+}
+
+func readPLYHeader(reader *bufio.Reader) (plyHeader, error) {
+	header := plyHeader{}
+
+	magic, err := readHeaderLine(reader)
+	if err != nil {
+		return header, err
+	}
+	if magic != "ply" {
+		return header, fmt.Errorf("not a ply file")
+	}
+
+	for {
+		line, err := readHeaderLine(reader)
+		if err != nil {
+			return header, err
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "format":
+			if len(fields) != 3 {
+				return header, fmt.Errorf("invalid format line %q", line)
+			}
+			switch fields[1] {
+			case "ascii", "binary_little_endian", "binary_big_endian":
+				header.format = fields[1]
+			default:
+				return header, fmt.Errorf("unknown format %q", fields[1])
+			}
+		case "element":
+			element, err := parsePLYElement(fields)
+			if err != nil {
+				return header, err
+			}
+			header.elements = append(header.elements, element)
+		case "property":
+			if len(header.elements) == 0 {
+				return header, fmt.Errorf("property before element: %q", line)
+			}
+			property, err := parsePLYProperty(fields)
+			if err != nil {
+				return header, err
+			}
+			element := &header.elements[len(header.elements)-1]
+			element.properties = append(element.properties, property)
+		case "comment", "obj_info":
+		case "end_header":
+			if header.format == "" {
+				return header, fmt.Errorf("missing format")
+			}
+			return header, nil
+		default:
+			return header, fmt.Errorf("unknown keyword %q", fields[0])
+		}
+	}
+}
+
+func readHeaderLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return "", io.ErrUnexpectedEOF
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func parsePLYElement(fields []string) (plyElement, error) {
+	if len(fields) != 3 {
+		return plyElement{}, fmt.Errorf("invalid element %q", strings.Join(fields, " "))
+	}
+	count, err := strconv.Atoi(fields[2])
+	if err != nil || count < 0 {
+		return plyElement{}, fmt.Errorf("invalid element count %q", fields[2])
+	}
+	return plyElement{name: fields[1], count: count}, nil
+}
+
+func parsePLYProperty(fields []string) (plyProperty, error) {
+	if len(fields) == 5 && fields[1] == "list" {
+		if !isPLYIntegerType(fields[2]) {
+			return plyProperty{}, fmt.Errorf("invalid list count type %q", fields[2])
+		}
+		if plyTypeSize(fields[3]) == 0 {
+			return plyProperty{}, fmt.Errorf("unknown property type %q", fields[3])
+		}
+		return plyProperty{name: fields[4], valueType: fields[3], countType: fields[2]}, nil
+	}
+
+	if len(fields) != 3 {
+		return plyProperty{}, fmt.Errorf("invalid property %q", strings.Join(fields, " "))
+	}
+	if plyTypeSize(fields[1]) == 0 {
+		return plyProperty{}, fmt.Errorf("unknown property type %q", fields[1])
+	}
+	return plyProperty{name: fields[2], valueType: fields[1]}, nil
+}
+
+func plyTypeSize(valueType string) int {
+	switch valueType {
+	case "char", "uchar", "int8", "uint8":
+		return 1
+	case "short", "ushort", "int16", "uint16":
+		return 2
+	case "int", "uint", "float", "int32", "uint32", "float32":
+		return 4
+	case "double", "float64":
+		return 8
+	default:
+		return 0
+	}
+}
+
+func isPLYIntegerType(valueType string) bool {
+	switch valueType {
+	case "float", "double", "float32", "float64":
+		return false
+	default:
+		return plyTypeSize(valueType) > 0
+	}
+}
+
+func readPLYBody(header plyHeader, values plyValueReader) (indexedTriangles, error) {
+	triangles := indexedTriangles{}
+	hasVertices := false
+
+	for _, element := range header.elements {
+		var err error
+		switch element.name {
+		case "vertex":
+			hasVertices = true
+			err = readPLYVertices(element, values, &triangles)
+		case "face":
+			if !hasVertices {
+				return triangles, fmt.Errorf("faces before vertices")
+			}
+			err = readPLYFaces(element, values, &triangles)
+		default:
+			err = skipPLYElement(element, values)
+		}
+		if err != nil {
+			return triangles, fmt.Errorf("element %s: %w", element.name, err)
+		}
+	}
+
+	return triangles, nil
+}
+
+func readPLYVertices(element plyElement, values plyValueReader, triangles *indexedTriangles) error {
+	x, y, z := element.propertyIndex("x"), element.propertyIndex("y"), element.propertyIndex("z")
+	if x < 0 || y < 0 || z < 0 {
+		return fmt.Errorf("missing x, y or z property")
+	}
+	nx, ny, nz := element.propertyIndex("nx"), element.propertyIndex("ny"), element.propertyIndex("nz")
+	hasNormals := nx >= 0 && ny >= 0 && nz >= 0
+
+	triangles.positions = make([]core.Vec3, 0, preallocated(element.count))
+	if hasNormals {
+		triangles.normals = make([]core.Vec3, 0, preallocated(element.count))
+	}
+
+	row := make([]float64, len(element.properties))
+	for i := 0; i < element.count; i++ {
+		if err := readPLYRow(element, values, row); err != nil {
+			return fmt.Errorf("vertex %d: %w", i, err)
+		}
+
+		triangles.positions = append(triangles.positions, plyVec3(row, x, y, z))
+		if hasNormals {
+			normal := plyVec3(row, nx, ny, nz)
+			if normal.LenSqr() == 0 {
+				// Scanned meshes often have zero normals, they are computed from the faces instead
+				hasNormals = false
+				triangles.normals = nil
+				continue
+			}
+			triangles.normals = append(triangles.normals, normal.Normalize())
+		}
+	}
+	return nil
+}
+
+// Reads a row of scalar properties, list properties are skipped.
+func readPLYRow(element plyElement, values plyValueReader, row []float64) error {
+	for i, property := range element.properties {
+		if property.isList() {
+			if _, err := readPLYList(property, values); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, err := values.read(property.valueType)
+		if err != nil {
+			return err
+		}
+		row[i] = value
+	}
+	return nil
+}
+
+func plyVec3(row []float64, x, y, z int) core.Vec3 {
+	return core.NewVec3(core.Real(row[x]), core.Real(row[y]), core.Real(row[z]))
+}
+
+func readPLYFaces(element plyElement, values plyValueReader, triangles *indexedTriangles) error {
+	indicesProperty := element.propertyIndex("vertex_indices", "vertex_index")
+	if indicesProperty < 0 || !element.properties[indicesProperty].isList() {
+		return fmt.Errorf("missing vertex_indices list property")
+	}
+
+	triangles.faces = make([][3]int, 0, preallocated(element.count))
+	for i := 0; i < element.count; i++ {
+		for j, property := range element.properties {
+			if j != indicesProperty {
+				if err := skipPLYProperty(property, values); err != nil {
+					return fmt.Errorf("face %d: %w", i, err)
+				}
+				continue
+			}
+
+			indices, err := readPLYList(property, values)
+			if err != nil {
+				return fmt.Errorf("face %d: %w", i, err)
+			}
+			if err := triangles.addPolygon(indices); err != nil {
+				return fmt.Errorf("face %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func readPLYList(property plyProperty, values plyValueReader) ([]int, error) {
+	count, err := values.read(property.countType)
+	if err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("negative list length %v", count)
+	}
+
+	list := make([]int, 0, preallocated(int(count)))
+	for i := 0; i < int(count); i++ {
+		value, err := values.read(property.valueType)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, int(value))
+	}
+	return list, nil
+}
+
+func skipPLYProperty(property plyProperty, values plyValueReader) error {
+	if property.isList() {
+		_, err := readPLYList(property, values)
+		return err
+	}
+	_, err := values.read(property.valueType)
+	return err
+}
+
+func skipPLYElement(element plyElement, values plyValueReader) error {
+	for i := 0; i < element.count; i++ {
+		for _, property := range element.properties {
+			if err := skipPLYProperty(property, values); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type plyValueReader interface {
+	read(valueType string) (float64, error)
+}
+
+type plyASCIIReader struct {
+	scanner *bufio.Scanner
+}
+
+func newPLYASCIIReader(reader io.Reader) *plyASCIIReader {
+	scanner := bufio.NewScanner(reader)
+	scanner.Split(bufio.ScanWords)
+	return &plyASCIIReader{scanner: scanner}
+}
+
+func (r *plyASCIIReader) read(valueType string) (float64, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return 0, err
+		}
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	token := r.scanner.Text()
+	if isPLYIntegerType(valueType) {
+		value, err := strconv.ParseInt(token, 10, 64)
+		return float64(value), err
+	}
+	return strconv.ParseFloat(token, 64)
+}
+
+type plyBinaryReader struct {
+	reader    io.Reader
+	byteOrder binary.ByteOrder
+	buffer    [8]byte
+}
+
+func (r *plyBinaryReader) read(valueType string) (float64, error) {
+	bytes := r.buffer[:plyTypeSize(valueType)]
+	if _, err := io.ReadFull(r.reader, bytes); err != nil {
+		if err == io.EOF {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+
+	switch valueType {
+	case "char", "int8":
+		return float64(int8(bytes[0])), nil
+	case "uchar", "uint8":
+		return float64(bytes[0]), nil
+	case "short", "int16":
+		return float64(int16(r.byteOrder.Uint16(bytes))), nil
+	case "ushort", "uint16":
+		return float64(r.byteOrder.Uint16(bytes)), nil
+	case "int", "int32":
+		return float64(int32(r.byteOrder.Uint32(bytes))), nil
+	case "uint", "uint32":
+		return float64(r.byteOrder.Uint32(bytes)), nil
+	case "float", "float32":
+		return float64(math.Float32frombits(r.byteOrder.Uint32(bytes))), nil
+	default:
+		return math.Float64frombits(r.byteOrder.Uint64(bytes)), nil
+	}
+}
+
+func preallocated(count int) int {
+	return core.IfElse(count < maxPreallocatedElements, count, maxPreallocatedElements)
+}
@@ -0,0 +1,203 @@
+package importers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+)
+
+const (
+	stlHeaderSize   = 80
+	stlTriangleSize = 50
+)
+
+// LoadSTLFile reads an STL mesh from disk.
+func LoadSTLFile(filename string) (geometries.Mesh, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return geometries.Mesh{}, fmt.Errorf("load stl: %w", err)
+	}
+	defer file.Close()
+
+	return ReadSTL(file)
+}
+
+// ReadSTL parses an STL mesh in ASCII or binary format.
+// STL stores a flat normal per facet. If all facet normals are zero, the vertices are
+// welded by position and smooth normals are computed instead.
+func ReadSTL(reader io.Reader) (geometries.Mesh, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return geometries.Mesh{}, fmt.Errorf("read stl: %w", err)
+	}
+
+	var facets []stlFacet
+	// Binary files may also start with "solid", so the size check comes first
+	if isBinarySTL(data) {
+		facets, err = readBinarySTL(data)
+	} else if bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("solid")) {
+		facets, err = readASCIISTL(data)
+	} else {
+		err = fmt.Errorf("neither ascii nor binary stl")
+	}
+	if err != nil {
+		return geometries.Mesh{}, fmt.Errorf("read stl: %w", err)
+	}
+
+	mesh, err := buildSTLMesh(facets)
+	if err != nil {
+		return geometries.Mesh{}, fmt.Errorf("read stl: %w", err)
+	}
+	return mesh, nil
+}
+
+type stlFacet struct {
+	normal   core.Vec3
+	vertices [3]core.Vec3
+}
+
+func isBinarySTL(data []byte) bool {
+	if len(data) < stlHeaderSize+4 {
+		return false
+	}
+	numTriangles := binary.LittleEndian.Uint32(data[stlHeaderSize:])
+	return uint64(len(data)) == stlHeaderSize+4+uint64(numTriangles)*stlTriangleSize
+}
+
+func readBinarySTL(data []byte) ([]stlFacet, error) {
+	numTriangles := int(binary.LittleEndian.Uint32(data[stlHeaderSize:]))
+	facets := make([]stlFacet, 0, numTriangles)
+
+	offset := stlHeaderSize + 4
+	for i := 0; i < numTriangles; i++ {
+		record := data[offset : offset+stlTriangleSize]
+		facet := stlFacet{normal: readBinarySTLVec3(record[0:])}
+		for j := range facet.vertices {
+			facet.vertices[j] = readBinarySTLVec3(record[12*(j+1):])
+		}
+		facets = append(facets, facet)
+		// Each record ends with a 2-byte attribute count, which we ignore
+		offset += stlTriangleSize
+	}
+	return facets, nil
+}
+
+func readBinarySTLVec3(data []byte) core.Vec3 {
+	x := math.Float32frombits(binary.LittleEndian.Uint32(data[0:]))
+	y := math.Float32frombits(binary.LittleEndian.Uint32(data[4:]))
+	z := math.Float32frombits(binary.LittleEndian.Uint32(data[8:]))
+	return core.NewVec3(core.Real(x), core.Real(y), core.Real(z))
+}
+
+func readASCIISTL(data []byte) ([]stlFacet, error) {
+	facets := []stlFacet{}
+	var facet stlFacet
+	numVertices := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "facet":
+			if len(fields) != 5 || fields[1] != "normal" {
+				return nil, fmt.Errorf("line %d: invalid facet", lineNumber)
+			}
+			normal, err := parseVec3(fields[2:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: facet normal: %w", lineNumber, err)
+			}
+			facet = stlFacet{normal: normal}
+			numVertices = 0
+		case "vertex":
+			if numVertices == 3 {
+				return nil, fmt.Errorf("line %d: facet with more than 3 vertices", lineNumber)
+			}
+			vertex, err := parseVec3(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: vertex: %w", lineNumber, err)
+			}
+			facet.vertices[numVertices] = vertex
+			numVertices++
+		case "endfacet":
+			if numVertices != 3 {
+				return nil, fmt.Errorf("line %d: facet with %d vertices", lineNumber, numVertices)
+			}
+			facets = append(facets, facet)
+		case "solid", "outer", "endloop", "endsolid":
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %q", lineNumber, fields[0])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+func buildSTLMesh(facets []stlFacet) (geometries.Mesh, error) {
+	if hasFacetNormals(facets) {
+		return buildFlatSTLMesh(facets)
+	}
+	triangles := weldSTLFacets(facets)
+	return triangles.buildMesh()
+}
+
+func hasFacetNormals(facets []stlFacet) bool {
+	for _, facet := range facets {
+		if facet.normal.LenSqr() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func buildFlatSTLMesh(facets []stlFacet) (geometries.Mesh, error) {
+	triangles := make([]geometries.Triangle, 0, len(facets))
+	for _, facet := range facets {
+		v0, v1, v2 := facet.vertices[0], facet.vertices[1], facet.vertices[2]
+		if !isDegenerate(v0, v1, v2) {
+			triangles = append(triangles, geometries.NewTriangle(v0, v1, v2))
+		}
+	}
+
+	if len(triangles) == 0 {
+		return geometries.Mesh{}, fmt.Errorf("mesh has no faces")
+	}
+	return geometries.NewMesh(triangles), nil
+}
+
+// STL is a triangle soup, vertices shared by facets are merged if their positions match exactly.
+func weldSTLFacets(facets []stlFacet) indexedTriangles {
+	triangles := indexedTriangles{}
+	vertexIndex := map[core.Vec3]int{}
+
+	for _, facet := range facets {
+		var face [3]int
+		for i, vertex := range facet.vertices {
+			index, ok := vertexIndex[vertex]
+			if !ok {
+				index = len(triangles.positions)
+				vertexIndex[vertex] = index
+				triangles.positions = append(triangles.positions, vertex)
+			}
+			face[i] = index
+		}
+		triangles.faces = append(triangles.faces, face)
+	}
+	return triangles
+}
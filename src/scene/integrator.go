@@ -0,0 +1,20 @@
+package scene
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+)
+
+// Integrator computes the color of a camera ray, implementing a light transport algorithm.
+type Integrator interface {
+	Integrate(ray core.Ray, scene *SceneImpl) color.Color
+}
+
+// Materials don't know when rays are traced, the scattered ray continues at the time of the incident one.
+func reflect(ray core.Ray, hit geometries.Hit) materials.Reflection {
+	reflection := hit.Material.Reflect(ray.Direction(), hit.SurfacePoint)
+	reflection.Ray = reflection.Ray.AtTime(ray.Time())
+	return reflection
+}
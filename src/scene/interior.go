@@ -0,0 +1,45 @@
+package scene
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+)
+
+// interior tracks the absorbing dielectrics that a path is inside of, the innermost one last.
+// Paths enter a dielectric when they are transmitted through its surface from the front side
+// and leave it when they are transmitted from the back side.
+type interior []materials.Absorbing
+
+// Fraction of light that travels along the ray up to the hit.
+func (i interior) transmittance(ray core.Ray, hit geometries.Hit) color.Color {
+	if len(i) == 0 {
+		return color.White
+	}
+	return i[len(i)-1].Transmittance(hit.Param * ray.Direction().Len())
+}
+
+// The interior of the scattered ray. It's a new slice, so that the interior of the ray stays intact.
+func (i interior) update(ray core.Ray, hit geometries.Hit, reflection materials.Reflection) interior {
+	absorbing, isAbsorbing := hit.Material.(materials.Absorbing)
+	if !isAbsorbing {
+		return i
+	}
+
+	incidentSide := ray.Direction().Dot(hit.Normal)
+	scatteredSide := reflection.Ray.Direction().Dot(hit.Normal)
+	if incidentSide*scatteredSide <= 0 {
+		// Reflected back to the same side
+		return i
+	}
+
+	if incidentSide < 0 {
+		return append(append(interior{}, i...), absorbing)
+	}
+	if len(i) == 0 {
+		// Left a dielectric that the path wasn't known to be inside, e.g. a camera inside glass
+		return i
+	}
+	return append(interior{}, i[:len(i)-1]...)
+}
@@ -0,0 +1,143 @@
+package scene
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+)
+
+type light struct {
+	surface geometries.Sampleable
+	emitter materials.Emitter
+}
+
+// Light sources whose geometries can't be sampled are skipped,
+// their light is only found by rays that hit them.
+func collectLights(objects []Object) []light {
+	lights := []light{}
+	for _, object := range objects {
+		emitter, isEmitter := object.Material.(materials.Emitter)
+		surface, isSampleable := object.Hittable.(geometries.Sampleable)
+		if isEmitter && isSampleable {
+			lights = append(lights, light{surface: surface, emitter: emitter})
+		}
+	}
+	return lights
+}
+
+// Next event estimation: picks a random light, samples a point on it and traces
+// a shadow ray towards the point to add its contribution at the hit.
+// The contribution is weighted against the material sampling the same direction.
+func (s *SceneImpl) sampleDirectLight(ray core.Ray, hit geometries.Hit, randomizer random.RandomGenerator) color.Color {
+	incidentDirection := ray.Direction()
+	if len(s.lights) == 0 {
+		return color.Black
+	}
+
+	lightIndex := int(randomizer.Real() * core.Real(len(s.lights)))
+	light := s.lights[core.IfElse(lightIndex < len(s.lights), lightIndex, len(s.lights)-1)]
+	lightSample := light.surface.Sample(hit.Point, randomizer)
+	// Each light is picked with probability 1/len(s.lights)
+	samplePDF := lightSample.PDF / core.Real(len(s.lights))
+	if samplePDF <= 0 || samplePDF == core.Inf() {
+		return color.Black
+	}
+
+	toLight := lightSample.Point.Sub(hit.Point)
+	brdf := hit.Material.BRDF(incidentDirection, toLight, hit.SurfacePoint)
+	if brdf == color.Black {
+		return color.Black
+	}
+
+	distance := toLight.Len()
+	direction := toLight.Div(distance)
+	transmittance := s.shadowTransmittance(core.NewRayAtTime(hit.Point, direction, ray.Time()), distance)
+	if transmittance == 0 {
+		return color.Black
+	}
+
+	cosine := scatteringCosine(direction, hit)
+	weight := powerHeuristic(samplePDF, hit.Material.PDF(incidentDirection, toLight, hit.SurfacePoint))
+	return brdf.MulColor(light.emitter.Emission(lightSample.SurfacePoint)).Mul(transmittance * weight * cosine / samplePDF)
+}
+
+// Surfaces receive light proportionally to the cosine to their normal, media don't have a surface.
+func scatteringCosine(direction core.Vec3, hit geometries.Hit) core.Real {
+	if _, isPhaseFunction := hit.Material.(materials.PhaseFunction); isPhaseFunction {
+		return 1
+	}
+	return core.Abs(direction.Dot(hit.Normal))
+}
+
+// Light that a scattered ray receives straight from a light source or the background.
+func (s *SceneImpl) directEmission(ray core.Ray, scatteringPDF core.Real) color.Color {
+	optionalHit := s.Intersect(ray)
+	if optionalHit.Empty() {
+		return s.BackgroundColor(ray)
+	}
+
+	hit := optionalHit.Value()
+	emitter, isEmitter := hit.Material.(materials.Emitter)
+	if !isEmitter {
+		return color.Black
+	}
+	return emitter.Emission(hit.SurfacePoint).Mul(s.emissionWeight(ray, hit, scatteringPDF))
+}
+
+// Weight of light found by a scattered ray, rays that light sampling couldn't produce get the full weight.
+func (s *SceneImpl) emissionWeight(ray core.Ray, hit geometries.Hit, scatteringPDF core.Real) core.Real {
+	if scatteringPDF == 0 {
+		return 1
+	}
+	return powerHeuristic(scatteringPDF, s.lightPDF(ray, hit))
+}
+
+// Density of light sampling picking the hit point, seen from the ray origin.
+// The hit light is the one that the ray also hits first, no other surface is closer.
+func (s *SceneImpl) lightPDF(ray core.Ray, hit geometries.Hit) core.Real {
+	params := core.NewInterval(s.minHitParam, hit.Param)
+	for _, light := range s.lights {
+		if light.surface.TestRay(ray, params).Present() {
+			return light.surface.PDF(ray.Origin(), hit) / core.Real(len(s.lights))
+		}
+	}
+	return 0
+}
+
+// https://www.pbr-book.org/3ed-2018/Monte_Carlo_Integration/Importance_Sampling#MultipleImportanceSampling
+func powerHeuristic(pdf, otherPDF core.Real) core.Real {
+	if pdf == core.Inf() {
+		return 1
+	}
+	return pdf * pdf / (pdf*pdf + otherPDF*otherPDF)
+}
+
+// Lights often lie flush with other surfaces, like a ceiling lamp. A relative tolerance at the far end
+// of the shadow ray keeps such surfaces from occluding the light.
+const shadowRayTolerance = 1e-3
+
+// Surfaces block light, participating media only attenuate it.
+// The direction of the shadow ray is a unit vector, so that its params measure the distance.
+func (s *SceneImpl) occluded(shadowRay core.Ray, distance core.Real) bool {
+	maxParam := distance * (1 - shadowRayTolerance)
+	if maxParam <= s.minHitParam {
+		return false
+	}
+	return s.bvh.TestRay(shadowRay, core.NewInterval(s.minHitParam, maxParam)).Present()
+}
+
+// Fraction of light travelling along the shadow ray over the distance.
+func (s *SceneImpl) shadowTransmittance(shadowRay core.Ray, distance core.Real) core.Real {
+	if s.occluded(shadowRay, distance) {
+		return 0
+	}
+
+	params := core.NewInterval(s.minHitParam, core.Max(s.minHitParam, distance*(1-shadowRayTolerance)))
+	transmittance := core.Real(1)
+	for _, medium := range s.media {
+		transmittance *= medium.Hittable.(geometries.Medium).Transmittance(shadowRay, params)
+	}
+	return transmittance
+}
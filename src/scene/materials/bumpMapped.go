@@ -0,0 +1,65 @@
+package materials
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+)
+
+// Step of the finite differences that estimate the height gradient.
+const bumpMapDelta = 1e-3
+
+// BumpMapped tilts the shading normal against the gradient of a height texture before
+// passing the surface to the wrapped material. Heights are the average of the color channels,
+// scaled by the strength. The gradient is estimated by shifting both the UV coordinates and
+// the point along the tangent frame, so UV-mapped and solid textures both work.
+type BumpMapped struct {
+	material  Material
+	heightMap textures.Texture
+	strength  core.Real
+}
+
+func NewBumpMapped(material Material, heightMap textures.Texture, strength core.Real) BumpMapped {
+	return BumpMapped{material: material, heightMap: heightMap, strength: strength}
+}
+
+func (m BumpMapped) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
+	return m.material.Reflect(incidentDirection, m.perturb(surface))
+}
+
+func (m BumpMapped) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	return m.material.BRDF(incidentDirection, scatteredDirection, m.perturb(surface))
+}
+
+func (m BumpMapped) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	return m.material.PDF(incidentDirection, scatteredDirection, m.perturb(surface))
+}
+
+func (m BumpMapped) perturb(surface core.SurfacePoint) core.SurfacePoint {
+	tangent, bitangent := tangentFrame(surface)
+	height := m.height(surface)
+	slopeU := (m.height(shift(surface, tangent, core.NewVec2(bumpMapDelta, 0))) - height) / bumpMapDelta
+	slopeV := (m.height(shift(surface, bitangent, core.NewVec2(0, bumpMapDelta))) - height) / bumpMapDelta
+
+	normal := surface.Normal.
+		Sub(tangent.Mul(m.strength * slopeU)).
+		Sub(bitangent.Mul(m.strength * slopeV)).
+		Normalize()
+	return withShadingNormal(surface, normal, tangent, bitangent)
+}
+
+func (m BumpMapped) height(surface core.SurfacePoint) core.Real {
+	return scalarAt(m.heightMap, surface)
+}
+
+// Textures holding single numbers are read as the average of the color channels.
+func scalarAt(texture textures.Texture, surface core.SurfacePoint) core.Real {
+	value := texture.ColorAt(surface)
+	return (value.R() + value.G() + value.B()) / 3
+}
+
+func shift(surface core.SurfacePoint, direction core.Vec3, uvOffset core.Vec2) core.SurfacePoint {
+	surface.Point = surface.Point.Add(direction.Mul(bumpMapDelta))
+	surface.UV = surface.UV.Add(uvOffset)
+	return surface
+}
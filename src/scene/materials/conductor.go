@@ -0,0 +1,138 @@
+package materials
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+// ComplexIOR is the complex index of refraction eta + ik of a metal per RGB channel,
+// at wavelengths of about 650, 550 and 450 nm.
+type ComplexIOR struct {
+	Eta color.Color
+	K   color.Color
+}
+
+// Measured metals, https://refractiveindex.info
+var (
+	GoldIOR      = ComplexIOR{Eta: color.New(0.18299, 0.42108, 1.37340), K: color.New(3.42420, 2.34590, 1.77040)}
+	CopperIOR    = ComplexIOR{Eta: color.New(0.27105, 0.67693, 1.31640), K: color.New(3.60920, 2.62480, 2.29210)}
+	AluminiumIOR = ComplexIOR{Eta: color.New(1.65746, 0.88014, 0.52123), K: color.New(9.22387, 6.26952, 4.83700)}
+	SilverIOR    = ComplexIOR{Eta: color.New(0.15943, 0.14512, 0.13547), K: color.New(3.92910, 3.19000, 2.38080)}
+	IronIOR      = ComplexIOR{Eta: color.New(2.91140, 2.94970, 2.58450), K: color.New(3.08930, 2.93180, 2.76700)}
+)
+
+// Conductor is a metal with a GGX microfacet surface. Roughness 0 makes it a perfect mirror.
+// Reflected directions are sampled from the visible microfacet normals, so the sample weights
+// stay close to the Fresnel reflectance.
+// https://www.pbr-book.org/3ed-2018/Reflection_Models/Microfacet_Models
+type Conductor struct {
+	ior          ComplexIOR
+	distribution ggx
+	randomizer   random.RandomGenerator
+}
+
+func NewConductor(ior ComplexIOR, roughness core.Real, randomizer random.RandomGenerator) Conductor {
+	if roughness < 0 || roughness > 1 {
+		panic(fmt.Errorf("roughness must be in range [0, 1], got %f", roughness))
+	}
+	return Conductor{ior: ior, distribution: newGGX(roughness), randomizer: randomizer}
+}
+
+func (c Conductor) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
+	frame := newShadingFrame(surface)
+	outgoing := frame.toLocal(incidentDirection.Normalize().Mul(-1))
+	if outgoing.Z() <= 0 {
+		return Reflection{Type: Absorbed}
+	}
+
+	if c.distribution.smooth() {
+		return Reflection{
+			Type:  Scattered,
+			Ray:   core.NewRay(surface.Point, incidentDirection.Reflect(surface.Normal)),
+			Color: fresnelConductor(outgoing.Z(), c.ior),
+		}
+	}
+
+	microNormal := c.distribution.sampleVisibleNormal(outgoing, c.randomizer.Real(), c.randomizer.Real())
+	incoming := reflectAround(outgoing, microNormal)
+	if incoming.Z() <= 0 {
+		return Reflection{Type: Absorbed}
+	}
+
+	// BRDF times cosine over PDF, most of the terms cancel out
+	weight := c.distribution.G(outgoing, incoming) / c.distribution.G1(outgoing)
+	return Reflection{
+		Type:  Scattered,
+		Ray:   core.NewRay(surface.Point, frame.toWorld(incoming)),
+		Color: fresnelConductor(outgoing.Dot(microNormal), c.ior).Mul(weight),
+	}
+}
+
+// Torrance-Sparrow microfacet reflection, it's black for perfect mirrors.
+func (c Conductor) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	outgoing, incoming, microNormal, ok := c.localDirections(incidentDirection, scatteredDirection, surface)
+	if !ok {
+		return color.Black
+	}
+
+	d := c.distribution.D(microNormal)
+	g := c.distribution.G(outgoing, incoming)
+	return fresnelConductor(outgoing.Dot(microNormal), c.ior).Mul(d * g / (4 * outgoing.Z() * incoming.Z()))
+}
+
+// The density of visible normals converted to reflected directions.
+func (c Conductor) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	outgoing, _, microNormal, ok := c.localDirections(incidentDirection, scatteredDirection, surface)
+	if !ok {
+		return 0
+	}
+	return c.distribution.visibleNormalPDF(outgoing, microNormal) / (4 * outgoing.Dot(microNormal))
+}
+
+// The outgoing direction towards the viewer, the incoming one towards the light and the half vector
+// between them in the shading frame. Not ok for smooth surfaces and directions below the surface.
+func (c Conductor) localDirections(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) (core.Vec3, core.Vec3, core.Vec3, bool) {
+	if c.distribution.smooth() {
+		return core.Vec3{}, core.Vec3{}, core.Vec3{}, false
+	}
+
+	frame := newShadingFrame(surface)
+	outgoing := frame.toLocal(incidentDirection.Normalize().Mul(-1))
+	incoming := frame.toLocal(scatteredDirection.Normalize())
+	if outgoing.Z() <= 0 || incoming.Z() <= 0 {
+		return core.Vec3{}, core.Vec3{}, core.Vec3{}, false
+	}
+	return outgoing, incoming, outgoing.Add(incoming).Normalize(), true
+}
+
+// Exact Fresnel reflectance of a conductor for unpolarized light coming from air.
+// https://seblagarde.wordpress.com/2013/04/29/memo-on-fresnel-equations/
+func fresnelConductor(cosine core.Real, ior ComplexIOR) color.Color {
+	return color.New(
+		fresnelConductorChannel(cosine, ior.Eta.R(), ior.K.R()),
+		fresnelConductorChannel(cosine, ior.Eta.G(), ior.K.G()),
+		fresnelConductorChannel(cosine, ior.Eta.B(), ior.K.B()))
+}
+
+func fresnelConductorChannel(cosine, eta, k core.Real) core.Real {
+	cosine = core.Max(0, core.Min(1, cosine))
+	cosine2 := cosine * cosine
+	sine2 := 1 - cosine2
+
+	t0 := eta*eta - k*k - sine2
+	a2PlusB2 := core.Sqrt(t0*t0 + 4*eta*eta*k*k)
+	a := core.Sqrt(core.Max(0, (a2PlusB2+t0)/2))
+
+	t1 := a2PlusB2 + cosine2
+	t2 := 2 * cosine * a
+	perpendicular := (t1 - t2) / (t1 + t2)
+
+	t3 := cosine2*a2PlusB2 + sine2*sine2
+	t4 := t2 * sine2
+	parallel := perpendicular * (t3 - t4) / (t3 + t4)
+
+	return (perpendicular + parallel) / 2
+}
@@ -4,22 +4,42 @@ import (
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
 )
 
 type Diffusive struct {
-	color      color.Color
+	texture    textures.Texture
 	randomizer random.RandomGenerator
 }
 
 func NewDiffusive(color color.Color, randomizer random.RandomGenerator) Diffusive {
-	return Diffusive{color, randomizer}
+	return NewDiffusiveTextured(textures.NewConstant(color), randomizer)
 }
 
-func (d Diffusive) Reflect(incidentDirection, hitPoint, normalAtHitPoint core.Vec3) Reflection {
-	reflectedDirection := normalAtHitPoint.Add(d.randomizer.Vec3InUnitSphere())
+func NewDiffusiveTextured(texture textures.Texture, randomizer random.RandomGenerator) Diffusive {
+	return Diffusive{texture, randomizer}
+}
+
+// Offsetting the normal by a random unit vector distributes the reflected
+// directions proportionally to the cosine, as Lambertian reflection requires.
+func (d Diffusive) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
+	reflectedDirection := surface.Normal.Add(d.randomizer.Vec3OnUnitSphere())
 	return Reflection{
 		Type:  Scattered,
-		Ray:   core.NewRay(hitPoint, reflectedDirection),
-		Color: d.color,
+		Ray:   core.NewRay(surface.Point, reflectedDirection),
+		Color: d.texture.ColorAt(surface),
 	}
 }
+
+// Lambertian reflection, scattered directions below the surface receive no light.
+func (d Diffusive) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	if scatteredDirection.Dot(surface.Normal) <= 0 {
+		return color.Black
+	}
+	return d.texture.ColorAt(surface).Div(core.Pi)
+}
+
+func (d Diffusive) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	cosine := scatteredDirection.Normalize().Dot(surface.Normal)
+	return core.IfElse(cosine > 0, cosine/core.Pi, 0)
+}
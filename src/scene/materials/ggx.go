@@ -0,0 +1,96 @@
+package materials
+
+import "github.com/Shamanskiy/go-ray-tracer/src/core"
+
+// Below this width, microfacet distributions are treated as perfectly smooth.
+const minMicrofacetAlpha = 1e-3
+
+// ggx is the isotropic GGX (Trowbridge-Reitz) distribution of microfacet normals.
+// Directions are given in the local shading frame with the macroscopic normal along Z.
+// https://www.cs.cornell.edu/~srm/publications/EGSR07-btdf.pdf
+type ggx struct {
+	alpha core.Real
+}
+
+// Perceptual roughness is squared, so that it changes the highlights evenly.
+func newGGX(roughness core.Real) ggx {
+	return ggx{alpha: roughness * roughness}
+}
+
+func (d ggx) smooth() bool {
+	return d.alpha < minMicrofacetAlpha
+}
+
+// D is the density of microfacet normals, projected onto the macroscopic surface it integrates to one.
+func (d ggx) D(microNormal core.Vec3) core.Real {
+	cosine := microNormal.Z()
+	if cosine <= 0 {
+		return 0
+	}
+	alpha2 := d.alpha * d.alpha
+	denominator := cosine*cosine*(alpha2-1) + 1
+	return alpha2 / (core.Pi * denominator * denominator)
+}
+
+// Smith's auxiliary function, the ratio of the back-facing to the front-facing
+// projected microfacet area in the given direction.
+func (d ggx) lambda(direction core.Vec3) core.Real {
+	cosine2 := direction.Z() * direction.Z()
+	if cosine2 == 0 {
+		return core.Inf()
+	}
+	tan2 := (1 - cosine2) / cosine2
+	return (core.Sqrt(1+d.alpha*d.alpha*tan2) - 1) / 2
+}
+
+// G1 is the fraction of microfacets visible from the direction.
+func (d ggx) G1(direction core.Vec3) core.Real {
+	return 1 / (1 + d.lambda(direction))
+}
+
+// G is the height-correlated Smith masking-shadowing term for a pair of directions.
+func (d ggx) G(outgoing, incoming core.Vec3) core.Real {
+	return 1 / (1 + d.lambda(outgoing) + d.lambda(incoming))
+}
+
+// visibleNormalPDF is the density of microfacet normals visible from the outgoing direction.
+// Backfacing microfacets must be rejected by the caller.
+func (d ggx) visibleNormalPDF(outgoing, microNormal core.Vec3) core.Real {
+	return d.G1(outgoing) * core.Abs(outgoing.Dot(microNormal)) * d.D(microNormal) / core.Abs(outgoing.Z())
+}
+
+// sampleVisibleNormal picks a microfacet normal proportionally to its area visible
+// from the outgoing direction. For directions below the surface, the normal is sampled as
+// seen from the mirrored direction above, so that it always points to the upper side.
+// https://jcgt.org/published/0007/04/01/
+func (d ggx) sampleVisibleNormal(outgoing core.Vec3, u1, u2 core.Real) core.Vec3 {
+	// Stretch the view to the configuration of a hemisphere of unit roughness
+	view := core.NewVec3(d.alpha*outgoing.X(), d.alpha*outgoing.Y(), outgoing.Z()).Normalize()
+	if view.Z() < 0 {
+		view = view.Mul(-1)
+	}
+
+	lengthSquared := view.X()*view.X() + view.Y()*view.Y()
+	tangent1 := core.NewVec3(1, 0, 0)
+	if lengthSquared > 0 {
+		tangent1 = core.NewVec3(-view.Y(), view.X(), 0).Div(core.Sqrt(lengthSquared))
+	}
+	tangent2 := view.Cross(tangent1)
+
+	// Uniform point on the disk, squeezed into the part of the hemisphere projection visible from the view
+	radius := core.Sqrt(u1)
+	phi := 2 * core.Pi * u2
+	t1 := radius * core.Cos(phi)
+	t2 := radius * core.Sin(phi)
+	s := (1 + view.Z()) / 2
+	t2 = (1-s)*core.Sqrt(1-t1*t1) + s*t2
+
+	normal := tangent1.Mul(t1).Add(tangent2.Mul(t2)).Add(view.Mul(core.Sqrt(core.Max(0, 1-t1*t1-t2*t2))))
+	// Unstretch back to the actual roughness
+	return core.NewVec3(d.alpha*normal.X(), d.alpha*normal.Y(), core.Max(0, normal.Z())).Normalize()
+}
+
+// reflectAround mirrors the outgoing direction around the microfacet normal.
+func reflectAround(outgoing, microNormal core.Vec3) core.Vec3 {
+	return microNormal.Mul(2 * outgoing.Dot(microNormal)).Sub(outgoing)
+}
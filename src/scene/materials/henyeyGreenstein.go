@@ -0,0 +1,76 @@
+package materials
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+// Below this asymmetry, the phase function is sampled as isotropic to avoid dividing by zero.
+const minPhaseAsymmetry = 1e-3
+
+// HenyeyGreenstein is the phase function of a participating medium. The albedo is the fraction
+// of light that is scattered rather than absorbed. Positive asymmetry scatters light forward,
+// negative asymmetry scatters it back, zero scatters it evenly in all directions.
+// https://www.pbr-book.org/3ed-2018/Volume_Scattering/Phase_Functions
+type HenyeyGreenstein struct {
+	albedo     color.Color
+	asymmetry  core.Real
+	randomizer random.RandomGenerator
+}
+
+func NewHenyeyGreenstein(albedo color.Color, asymmetry core.Real, randomizer random.RandomGenerator) HenyeyGreenstein {
+	if asymmetry <= -1 || asymmetry >= 1 {
+		panic(fmt.Errorf("asymmetry must be in range (-1, 1), got %f", asymmetry))
+	}
+	return HenyeyGreenstein{albedo: albedo, asymmetry: asymmetry, randomizer: randomizer}
+}
+
+func NewIsotropic(albedo color.Color, randomizer random.RandomGenerator) HenyeyGreenstein {
+	return NewHenyeyGreenstein(albedo, 0, randomizer)
+}
+
+// Scattered directions are sampled exactly with the phase function, so the weight is the albedo.
+func (m HenyeyGreenstein) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
+	forward := incidentDirection.Normalize()
+	cosine := m.sampleCosine(m.randomizer.Real())
+	sine := core.Sqrt(core.Max(0, 1-cosine*cosine))
+	phi := 2 * core.Pi * m.randomizer.Real()
+
+	tangent, bitangent := core.OrthonormalBasis(forward)
+	scatteredDirection := forward.Mul(cosine).
+		Add(tangent.Mul(sine * core.Cos(phi))).
+		Add(bitangent.Mul(sine * core.Sin(phi)))
+	return Reflection{
+		Type:  Scattered,
+		Ray:   core.NewRay(surface.Point, scatteredDirection),
+		Color: m.albedo,
+	}
+}
+
+// The cosine between the incident and the scattered directions, by inverting the cumulative distribution.
+func (m HenyeyGreenstein) sampleCosine(u core.Real) core.Real {
+	g := m.asymmetry
+	if core.Abs(g) < minPhaseAsymmetry {
+		return 1 - 2*u
+	}
+	ratio := (1 - g*g) / (1 - g + 2*g*u)
+	return core.Max(-1, core.Min(1, (1+g*g-ratio*ratio)/(2*g)))
+}
+
+func (m HenyeyGreenstein) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	return m.albedo.Mul(m.Phase(incidentDirection, scatteredDirection))
+}
+
+func (m HenyeyGreenstein) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	return m.Phase(incidentDirection, scatteredDirection)
+}
+
+func (m HenyeyGreenstein) Phase(incidentDirection, scatteredDirection core.Vec3) core.Real {
+	g := m.asymmetry
+	cosine := incidentDirection.Normalize().Dot(scatteredDirection.Normalize())
+	denominator := 1 + g*g - 2*g*cosine
+	return (1 - g*g) / (4 * core.Pi * denominator * core.Sqrt(denominator))
+}
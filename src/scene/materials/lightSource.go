@@ -3,20 +3,37 @@ package materials
 import (
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
 )
 
 type DiffusiveLight struct {
-	color     color.Color
+	texture   textures.Texture
 	intensity core.Real
 }
 
 func NewDiffusiveLight(color color.Color, intensity core.Real) DiffusiveLight {
-	return DiffusiveLight{color: color, intensity: intensity}
+	return NewDiffusiveLightTextured(textures.NewConstant(color), intensity)
 }
 
-func (d DiffusiveLight) Reflect(incidentDirection, hitPoint, normalAtHitPoint core.Vec3) Reflection {
+func NewDiffusiveLightTextured(texture textures.Texture, intensity core.Real) DiffusiveLight {
+	return DiffusiveLight{texture: texture, intensity: intensity}
+}
+
+func (d DiffusiveLight) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
 	return Reflection{
 		Type:  Emitted,
-		Color: d.color.Mul(d.intensity),
+		Color: d.Emission(surface),
 	}
 }
+
+func (d DiffusiveLight) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	return color.Black
+}
+
+func (d DiffusiveLight) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	return 0
+}
+
+func (d DiffusiveLight) Emission(surface core.SurfacePoint) color.Color {
+	return d.texture.ColorAt(surface).Mul(d.intensity)
+}
@@ -20,5 +20,34 @@ type Reflection struct {
 }
 
 type Material interface {
-	Reflect(incidentDirection, hitPoint, normalAtHitPoint core.Vec3) Reflection
+	Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection
+	// BRDF returns the fraction of light arriving along the scattered direction that is
+	// reflected towards the incident ray. Materials that scatter into discrete directions
+	// return black, since light sampling can't hit those directions.
+	BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color
+	// PDF returns the probability density of Reflect scattering into the given direction,
+	// with respect to solid angle. It's zero for materials with discrete scattering directions.
+	PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real
+}
+
+// Absorbing materials are dielectrics that absorb light travelling inside them.
+// Integrators track which of them a path is inside of to apply the absorption.
+type Absorbing interface {
+	Material
+	// Transmittance returns the fraction of light that isn't absorbed over the distance inside the material.
+	Transmittance(distance core.Real) color.Color
+}
+
+// Emitter materials are light sources. Scenes can sample objects made of them directly.
+type Emitter interface {
+	Material
+	Emission(surface core.SurfacePoint) color.Color
+}
+
+// PhaseFunction materials scatter light inside participating media. Scattering doesn't depend
+// on a surface orientation, so integrators don't apply the cosine to the light they scatter.
+type PhaseFunction interface {
+	Material
+	// Phase returns the density of scattering from the incident direction into the scattered one.
+	Phase(incidentDirection, scatteredDirection core.Vec3) core.Real
 }
@@ -0,0 +1,50 @@
+package materials
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+)
+
+// NormalMapped replaces the shading normal with one from a tangent-space normal map
+// before passing the surface to the wrapped material. The map encodes normals as colors
+// (n + 1) / 2, so image maps should be loaded with textures.LoadLinearImageFile.
+type NormalMapped struct {
+	material  Material
+	normalMap textures.Texture
+}
+
+func NewNormalMapped(material Material, normalMap textures.Texture) NormalMapped {
+	return NormalMapped{material: material, normalMap: normalMap}
+}
+
+func (m NormalMapped) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
+	return m.material.Reflect(incidentDirection, m.perturb(surface))
+}
+
+func (m NormalMapped) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	return m.material.BRDF(incidentDirection, scatteredDirection, m.perturb(surface))
+}
+
+func (m NormalMapped) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	return m.material.PDF(incidentDirection, scatteredDirection, m.perturb(surface))
+}
+
+func (m NormalMapped) perturb(surface core.SurfacePoint) core.SurfacePoint {
+	encoded := m.normalMap.ColorAt(surface)
+	tangent, bitangent := tangentFrame(surface)
+	normal := tangent.Mul(2*encoded.R() - 1).
+		Add(bitangent.Mul(2*encoded.G() - 1)).
+		Add(surface.Normal.Mul(2*encoded.B() - 1))
+	if normal.LenSqr() == 0 {
+		return surface
+	}
+	return withShadingNormal(surface, normal.Normalize(), tangent, bitangent)
+}
+
+// The tangent frame follows the new normal, so that perturbations can be stacked.
+func withShadingNormal(surface core.SurfacePoint, normal, tangent, bitangent core.Vec3) core.SurfacePoint {
+	surface.Normal = normal
+	surface.Tangent, surface.Bitangent = core.TangentFrame(normal, tangent, bitangent)
+	return surface
+}
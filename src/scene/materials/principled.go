@@ -0,0 +1,266 @@
+package materials
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+)
+
+const DEFAULT_PRINCIPLED_IOR = 1.45
+
+// Roughness is kept above this value, so that all lobes of the principled material can be
+// sampled by light sampling as well.
+const minPrincipledRoughness = 0.05
+
+// Reflectance of the clearcoat layer at normal incidence, a polyurethane with refraction index 1.5.
+const clearcoatReflectance = 0.04
+
+// Principled is a layered material after the Disney principled BSDF. A diffuse base with sheen is
+// blended with a GGX specular lobe, which turns into a metal as the metallic parameter grows.
+// A clearcoat lobe lies on top, and the transmission blends the base into rough glass.
+// Every parameter is a texture, single-number parameters take the average of the color channels
+// and are clamped to [0, 1]. Without transmission, both sides of a surface look the same.
+// https://media.disneyanimation.com/uploads/production/publication_asset/48/asset/s2012_pbs_disney_brdf_notes_v3.pdf
+type Principled struct {
+	baseColor          textures.Texture
+	metallic           textures.Texture
+	roughness          textures.Texture
+	specular           textures.Texture
+	specularTint       textures.Texture
+	sheen              textures.Texture
+	sheenTint          textures.Texture
+	clearcoat          textures.Texture
+	clearcoatRoughness textures.Texture
+	transmission       textures.Texture
+	ior                core.Real
+	randomizer         random.RandomGenerator
+}
+
+func NewPrincipled(randomizer random.RandomGenerator, settings ...PrincipledSetting) Principled {
+	principled := Principled{
+		baseColor:          textures.NewConstant(color.GrayLight),
+		metallic:           textures.NewScalar(0),
+		roughness:          textures.NewScalar(0.5),
+		specular:           textures.NewScalar(0.5),
+		specularTint:       textures.NewScalar(0),
+		sheen:              textures.NewScalar(0),
+		sheenTint:          textures.NewScalar(0.5),
+		clearcoat:          textures.NewScalar(0),
+		clearcoatRoughness: textures.NewScalar(0.1),
+		transmission:       textures.NewScalar(0),
+		ior:                DEFAULT_PRINCIPLED_IOR,
+		randomizer:         randomizer,
+	}
+	for _, setting := range settings {
+		setting(&principled)
+	}
+	return principled
+}
+
+// Picks one lobe with a probability following its estimated albedo and samples it.
+// The sample is weighted with the density of all lobes together, so the choice doesn't bias the result.
+func (m Principled) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
+	lobes := m.lobesAt(surface)
+	frame, outgoing := lobes.frame(incidentDirection, surface)
+	probabilities := lobes.probabilities(outgoing)
+	if probabilities == [lobeCount]core.Real{} {
+		return Reflection{Type: Absorbed}
+	}
+
+	scatteredDirection := core.Vec3{}
+	switch choice := m.randomizer.Real(); {
+	case choice < probabilities[diffuseLobe]:
+		incoming := core.NewVec3(0, 0, 1).Add(m.randomizer.Vec3OnUnitSphere())
+		scatteredDirection = frame.toWorld(incoming)
+	case choice < probabilities[diffuseLobe]+probabilities[specularLobe]:
+		scatteredDirection = frame.toWorld(lobes.specularDistribution.sampleReflection(outgoing, m.randomizer))
+	case choice < 1-probabilities[transmissionLobe]:
+		scatteredDirection = frame.toWorld(lobes.clearcoatDistribution.sampleReflection(outgoing, m.randomizer))
+	default:
+		reflection := lobes.transmission.Reflect(incidentDirection, surface)
+		if reflection.Type != Scattered {
+			return reflection
+		}
+		scatteredDirection = reflection.Ray.Direction()
+	}
+	if scatteredDirection.LenSqr() == 0 {
+		return Reflection{Type: Absorbed}
+	}
+
+	brdf, pdf := lobes.evaluate(incidentDirection, scatteredDirection.Normalize(), surface)
+	if pdf == 0 {
+		return Reflection{Type: Absorbed}
+	}
+	cosine := core.Abs(scatteredDirection.Normalize().Dot(surface.Normal))
+	return Reflection{
+		Type:  Scattered,
+		Ray:   core.NewRay(surface.Point, scatteredDirection),
+		Color: brdf.Mul(cosine / pdf),
+	}
+}
+
+func (m Principled) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	brdf, _ := m.lobesAt(surface).evaluate(incidentDirection, scatteredDirection.Normalize(), surface)
+	return brdf
+}
+
+func (m Principled) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	_, pdf := m.lobesAt(surface).evaluate(incidentDirection, scatteredDirection.Normalize(), surface)
+	return pdf
+}
+
+const (
+	diffuseLobe = iota
+	specularLobe
+	clearcoatLobe
+	transmissionLobe
+	lobeCount
+)
+
+// principledLobes holds the parameters of a principled material evaluated at a surface point.
+type principledLobes struct {
+	baseColor     color.Color
+	specularColor color.Color // reflectance at normal incidence
+	sheenColor    color.Color
+	roughness     core.Real
+
+	weights               [lobeCount]core.Real
+	specularDistribution  ggx
+	clearcoatDistribution ggx
+	transmission          RoughDielectric
+}
+
+func (m Principled) lobesAt(surface core.SurfacePoint) principledLobes {
+	baseColor := m.baseColor.ColorAt(surface)
+	metallic := unitScalarAt(m.metallic, surface)
+	roughness := core.Max(minPrincipledRoughness, unitScalarAt(m.roughness, surface))
+	transmission := unitScalarAt(m.transmission, surface)
+
+	tint := color.White
+	if luminance := averageChannel(baseColor); luminance > 0 {
+		tint = baseColor.Div(luminance)
+	}
+	dielectricSpecular := color.Interpolate(color.White, tint, unitScalarAt(m.specularTint, surface)).
+		Mul(0.08 * unitScalarAt(m.specular, surface))
+	sheenColor := color.Interpolate(color.White, tint, unitScalarAt(m.sheenTint, surface)).
+		Mul(unitScalarAt(m.sheen, surface))
+	clearcoatRoughness := core.Max(minPrincipledRoughness, unitScalarAt(m.clearcoatRoughness, surface))
+
+	return principledLobes{
+		baseColor:     baseColor,
+		specularColor: color.Interpolate(dielectricSpecular, baseColor, metallic),
+		sheenColor:    sheenColor,
+		roughness:     roughness,
+		weights: [lobeCount]core.Real{
+			diffuseLobe:      (1 - metallic) * (1 - transmission),
+			specularLobe:     1 - (1-metallic)*transmission,
+			clearcoatLobe:    unitScalarAt(m.clearcoat, surface) / 4,
+			transmissionLobe: (1 - metallic) * transmission,
+		},
+		specularDistribution:  newGGX(roughness),
+		clearcoatDistribution: newGGX(clearcoatRoughness),
+		transmission:          NewRoughDielectric(m.ior, roughness, baseColor, m.randomizer),
+	}
+}
+
+func unitScalarAt(texture textures.Texture, surface core.SurfacePoint) core.Real {
+	return core.Max(0, core.Min(1, scalarAt(texture, surface)))
+}
+
+func averageChannel(value color.Color) core.Real {
+	return (value.R() + value.G() + value.B()) / 3
+}
+
+// The shading frame of the opaque lobes and the outgoing direction in it. Without transmission,
+// surfaces hit from below are shaded as if hit from above.
+func (l principledLobes) frame(incidentDirection core.Vec3, surface core.SurfacePoint) (shadingFrame, core.Vec3) {
+	frame := newShadingFrame(surface)
+	outgoing := frame.toLocal(incidentDirection.Normalize().Mul(-1))
+	if outgoing.Z() < 0 && l.weights[transmissionLobe] == 0 {
+		frame.normal = frame.normal.Mul(-1)
+		frame.bitangent = frame.bitangent.Mul(-1)
+		outgoing = core.NewVec3(outgoing.X(), -outgoing.Y(), -outgoing.Z())
+	}
+	return frame, outgoing
+}
+
+// The probabilities of sampling each lobe follow their weights times their rough albedo estimates.
+// Below the surface, only the transmission lobe scatters.
+func (l principledLobes) probabilities(outgoing core.Vec3) [lobeCount]core.Real {
+	probabilities := [lobeCount]core.Real{transmissionLobe: l.weights[transmissionLobe]}
+	if outgoing.Z() > 0 {
+		probabilities[diffuseLobe] = l.weights[diffuseLobe] * (averageChannel(l.baseColor) + averageChannel(l.sheenColor))
+		probabilities[specularLobe] = l.weights[specularLobe] * averageChannel(schlickFresnel(l.specularColor, outgoing.Z()))
+		probabilities[clearcoatLobe] = l.weights[clearcoatLobe] * averageChannel(schlickFresnel(color.White.Mul(clearcoatReflectance), outgoing.Z()))
+	}
+
+	total := core.Real(0)
+	for _, probability := range probabilities {
+		total += probability
+	}
+	for i := range probabilities {
+		probabilities[i] = core.IfElse(total > 0, probabilities[i]/total, 0)
+	}
+	return probabilities
+}
+
+// The sum of the weighted lobes and the density of sampling the scattered direction with any of them.
+func (l principledLobes) evaluate(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) (color.Color, core.Real) {
+	frame, outgoing := l.frame(incidentDirection, surface)
+	incoming := frame.toLocal(scatteredDirection)
+	probabilities := l.probabilities(outgoing)
+
+	brdf, pdf := color.Black, core.Real(0)
+	if probabilities[transmissionLobe] > 0 {
+		brdf = l.transmission.BRDF(incidentDirection, scatteredDirection, surface).Mul(l.weights[transmissionLobe])
+		pdf = l.transmission.PDF(incidentDirection, scatteredDirection, surface) * probabilities[transmissionLobe]
+	}
+	if outgoing.Z() <= 0 || incoming.Z() <= 0 {
+		return brdf, pdf
+	}
+
+	halfVector := outgoing.Add(incoming).Normalize()
+	brdf = brdf.
+		Add(l.diffuse(outgoing, incoming, halfVector).Mul(l.weights[diffuseLobe])).
+		Add(l.specularDistribution.reflection(outgoing, incoming, halfVector, l.specularColor).Mul(l.weights[specularLobe])).
+		Add(l.clearcoatDistribution.reflection(outgoing, incoming, halfVector, color.White.Mul(clearcoatReflectance)).Mul(l.weights[clearcoatLobe]))
+	pdf += probabilities[diffuseLobe]*incoming.Z()/core.Pi +
+		probabilities[specularLobe]*l.specularDistribution.reflectionPDF(outgoing, halfVector) +
+		probabilities[clearcoatLobe]*l.clearcoatDistribution.reflectionPDF(outgoing, halfVector)
+	return brdf, pdf
+}
+
+// Burley's diffuse with retroreflection at grazing angles on rough surfaces, plus the sheen.
+func (l principledLobes) diffuse(outgoing, incoming, halfVector core.Vec3) color.Color {
+	cosineD := incoming.Dot(halfVector)
+	grazingRetroreflection := 0.5 + 2*l.roughness*cosineD*cosineD
+	diffuse := (1 + (grazingRetroreflection-1)*schlickWeight(incoming.Z())) *
+		(1 + (grazingRetroreflection-1)*schlickWeight(outgoing.Z())) / core.Pi
+	return l.baseColor.Mul(diffuse).Add(l.sheenColor.Mul(schlickWeight(cosineD)))
+}
+
+// Torrance-Sparrow reflection with the Schlick approximation of the Fresnel term,
+// for directions above the surface.
+func (d ggx) reflection(outgoing, incoming, halfVector core.Vec3, normalReflectance color.Color) color.Color {
+	value := d.D(halfVector) * d.G(outgoing, incoming) / (4 * outgoing.Z() * incoming.Z())
+	return schlickFresnel(normalReflectance, outgoing.Dot(halfVector)).Mul(value)
+}
+
+func (d ggx) reflectionPDF(outgoing, halfVector core.Vec3) core.Real {
+	return d.visibleNormalPDF(outgoing, halfVector) / (4 * outgoing.Dot(halfVector))
+}
+
+// Reflects the outgoing direction around a sampled visible normal, the result may go below the surface.
+func (d ggx) sampleReflection(outgoing core.Vec3, randomizer random.RandomGenerator) core.Vec3 {
+	return reflectAround(outgoing, d.sampleVisibleNormal(outgoing, randomizer.Real(), randomizer.Real()))
+}
+
+func schlickFresnel(normalReflectance color.Color, cosine core.Real) color.Color {
+	return color.Interpolate(normalReflectance, color.White, schlickWeight(cosine))
+}
+
+func schlickWeight(cosine core.Real) core.Real {
+	m := core.Max(0, core.Min(1, 1-cosine))
+	return m * m * m * m * m
+}
@@ -0,0 +1,86 @@
+package materials
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+)
+
+type PrincipledSetting func(*Principled)
+
+func BaseColor(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.baseColor = texture
+	}
+}
+
+// Metallic blends the dielectric base into a metal that reflects with the base color.
+func Metallic(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.metallic = texture
+	}
+}
+
+func Roughness(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.roughness = texture
+	}
+}
+
+// Specular scales the reflectance of dielectrics at normal incidence, 0.5 corresponds to 4%.
+func Specular(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.specular = texture
+	}
+}
+
+// SpecularTint tints the dielectric reflection towards the hue of the base color.
+func SpecularTint(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.specularTint = texture
+	}
+}
+
+// Sheen adds a soft grazing reflection, as on cloth.
+func Sheen(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.sheen = texture
+	}
+}
+
+func SheenTint(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.sheenTint = texture
+	}
+}
+
+// Clearcoat adds a second, colorless specular layer on top.
+func Clearcoat(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.clearcoat = texture
+	}
+}
+
+func ClearcoatRoughness(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.clearcoatRoughness = texture
+	}
+}
+
+// Transmission blends the dielectric base into rough glass tinted with the base color.
+func Transmission(texture textures.Texture) PrincipledSetting {
+	return func(principled *Principled) {
+		principled.transmission = texture
+	}
+}
+
+// IOR is the refraction index of the transmitted light, the reflections follow Specular.
+func IOR(refractionIndex core.Real) PrincipledSetting {
+	if refractionIndex < 1 {
+		panic(fmt.Errorf("refractionIndex must be at least 1, got %f", refractionIndex))
+	}
+	return func(principled *Principled) {
+		principled.ior = refractionIndex
+	}
+}
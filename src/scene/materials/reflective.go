@@ -6,45 +6,79 @@ import (
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
 )
 
 type Reflective struct {
-	color      color.Color
+	texture    textures.Texture
 	fuzziness  core.Real
 	randomizer random.RandomGenerator
 }
 
 func NewReflective(color color.Color, randomizer random.RandomGenerator) Reflective {
-	return Reflective{
-		color:      color,
-		fuzziness:  0,
-		randomizer: randomizer,
-	}
+	return NewReflectiveTextured(textures.NewConstant(color), 0, randomizer)
 }
 
 func NewReflectiveFuzzy(color color.Color, fuzziness core.Real, randomizer random.RandomGenerator) Reflective {
+	return NewReflectiveTextured(textures.NewConstant(color), fuzziness, randomizer)
+}
+
+func NewReflectiveTextured(texture textures.Texture, fuzziness core.Real, randomizer random.RandomGenerator) Reflective {
 	if fuzziness < 0 || fuzziness > 1 {
 		panic(fmt.Errorf("fuzziness must be in range [0, 1], got %f", fuzziness))
 	}
 	return Reflective{
-		color:      color,
+		texture:    texture,
 		fuzziness:  fuzziness,
 		randomizer: randomizer,
 	}
 }
 
-func (r Reflective) Reflect(incidentDirection, hitPoint, normalAtHitPoint core.Vec3) Reflection {
-	reflectedDirection := incidentDirection.Normalize().Reflect(normalAtHitPoint)
+func (r Reflective) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
+	reflectedDirection := incidentDirection.Normalize().Reflect(surface.Normal)
 	fuzzyPerturbation := r.randomizer.Vec3InUnitSphere().Mul(r.fuzziness)
 	reflectedDirection = reflectedDirection.Add(fuzzyPerturbation)
 
-	if reflectedDirection.Dot(normalAtHitPoint) > 0 {
+	if reflectedDirection.Dot(surface.Normal) > 0 {
 		return Reflection{
 			Type:  Scattered,
-			Ray:   core.NewRay(hitPoint, reflectedDirection),
-			Color: r.color,
+			Ray:   core.NewRay(surface.Point, reflectedDirection),
+			Color: r.texture.ColorAt(surface),
 		}
 	} else {
 		return Reflection{Type: Absorbed}
 	}
 }
+
+// Fuzzy reflection has no closed-form BRDF, it's the one implied by the sampling: Reflect returns
+// the material color as the sample weight, so the BRDF times cosine must equal color times PDF.
+func (r Reflective) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	cosine := scatteredDirection.Normalize().Dot(surface.Normal)
+	if cosine <= 0 {
+		return color.Black
+	}
+	return r.texture.ColorAt(surface).Mul(r.PDF(incidentDirection, scatteredDirection, surface) / cosine)
+}
+
+// Reflect offsets the mirrored direction by a random point in a ball of radius fuzziness.
+// The density of a direction is the ball volume along the ray in this direction, with the
+// volume element t^2 dt dω integrated over the chord [t1, t2] and divided by the ball volume.
+func (r Reflective) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	if r.fuzziness == 0 {
+		return 0
+	}
+
+	mirrored := incidentDirection.Normalize().Reflect(surface.Normal)
+	projection := scatteredDirection.Normalize().Dot(mirrored)
+	discriminant := projection*projection - 1 + r.fuzziness*r.fuzziness
+	if discriminant <= 0 {
+		return 0
+	}
+
+	t1 := core.Max(0, projection-core.Sqrt(discriminant))
+	t2 := projection + core.Sqrt(discriminant)
+	if t2 <= 0 {
+		return 0
+	}
+	return (t2*t2*t2 - t1*t1*t1) / (4 * core.Pi * r.fuzziness * r.fuzziness * r.fuzziness)
+}
@@ -1,9 +1,6 @@
 package materials
 
-import (
-	"github.com/Shamanskiy/go-ray-tracer/src/core"
-	"github.com/chewxy/math32"
-)
+import "github.com/Shamanskiy/go-ray-tracer/src/core"
 
 type Refraction struct {
 	Direction       *core.Vec3
@@ -42,13 +39,13 @@ func comesFromOutside(incidentDirection core.Vec3, normal core.Vec3) bool {
 func (r RefractionCalculator) schlickLaw(cosOutsideMaterial core.Real) core.Real {
 	r0 := (1 - r.RefractionIndex) / (1 + r.RefractionIndex)
 	r0 *= r0
-	return r0 + (1-r0)*math32.Pow(1-cosOutsideMaterial, 5)
+	return r0 + (1-r0)*core.Pow(1-cosOutsideMaterial, 5)
 }
 
 func (r RefractionCalculator) refractEnterMaterial(inDir core.Vec3, normal core.Vec3) Refraction {
 	inDotNormal := inDir.Dot(normal)
-	cosIn := math32.Abs(inDotNormal / inDir.Len())
-	cosOut := math32.Sqrt(1 - (1-cosIn*cosIn)/(r.RefractionIndex*r.RefractionIndex))
+	cosIn := core.Abs(inDotNormal / inDir.Len())
+	cosOut := core.Sqrt(1 - (1-cosIn*cosIn)/(r.RefractionIndex*r.RefractionIndex))
 
 	inNormalComponent := normal.Mul(inDotNormal)
 	inTangentComponent := inDir.Sub(inNormalComponent)
@@ -72,7 +69,7 @@ func (r RefractionCalculator) refractExitMaterial(inDir core.Vec3, normal core.V
 		return Refraction{nil, 1.}
 	}
 
-	cosOut := math32.Sqrt(cosOutSquared)
+	cosOut := core.Sqrt(cosOutSquared)
 	inNormalComponent := normal.Mul(inDotNormal)
 	inTangentComponent := inDir.Sub(inNormalComponent)
 
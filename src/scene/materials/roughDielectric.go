@@ -0,0 +1,164 @@
+package materials
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+// RoughDielectric is glass with a GGX microfacet surface, it both reflects and transmits light
+// around the sampled microfacet normals. Roughness 0 makes it as smooth as Transparent.
+// Like Transparent, it doesn't scale radiance by the squared index ratio at the boundary,
+// the factors cancel out for rays passing through closed objects.
+// https://www.cs.cornell.edu/~srm/publications/EGSR07-btdf.pdf
+type RoughDielectric struct {
+	color        color.Color
+	refractor    RefractionCalculator
+	distribution ggx
+	randomizer   random.RandomGenerator
+}
+
+func NewRoughDielectric(refractionIndex, roughness core.Real, color color.Color, randomizer random.RandomGenerator) RoughDielectric {
+	if refractionIndex < 1 {
+		panic(fmt.Errorf("refractionIndex must be at least 1, got %f", refractionIndex))
+	}
+	if roughness < 0 || roughness > 1 {
+		panic(fmt.Errorf("roughness must be in range [0, 1], got %f", roughness))
+	}
+	return RoughDielectric{
+		color:        color,
+		refractor:    NewRefractionCalculator(refractionIndex),
+		distribution: newGGX(roughness),
+		randomizer:   randomizer,
+	}
+}
+
+// Picks reflection or refraction around the sampled microfacet normal with the Fresnel
+// probability, so the sample weight doesn't depend on the Fresnel term.
+func (m RoughDielectric) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
+	frame := newShadingFrame(surface)
+	incidentDirection = incidentDirection.Normalize()
+	outgoing := frame.toLocal(incidentDirection.Mul(-1))
+	if outgoing.Z() == 0 {
+		return Reflection{Type: Absorbed}
+	}
+
+	microNormal := core.NewVec3(0, 0, 1)
+	if !m.distribution.smooth() {
+		microNormal = m.distribution.sampleVisibleNormal(outgoing, m.randomizer.Real(), m.randomizer.Real())
+	}
+	worldMicroNormal := frame.toWorld(microNormal)
+
+	refraction := m.refractor.Refract(incidentDirection, worldMicroNormal)
+	scatteredDirection := incidentDirection.Reflect(worldMicroNormal)
+	if !refraction.FullInternalReflection() && m.randomizer.Real() > refraction.ReflectionRatio {
+		scatteredDirection = *refraction.Direction
+	}
+
+	incoming := frame.toLocal(scatteredDirection)
+	isReflection := incidentDirection.Dot(worldMicroNormal)*scatteredDirection.Dot(worldMicroNormal) < 0
+	if isReflection != (outgoing.Z()*incoming.Z() > 0) {
+		// Scattered to the wrong side of the macroscopic surface
+		return Reflection{Type: Absorbed}
+	}
+
+	weight := m.distribution.G(outgoing, incoming) / m.distribution.G1(outgoing)
+	return Reflection{
+		Type:  Scattered,
+		Ray:   core.NewRay(surface.Point, scatteredDirection),
+		Color: m.color.Mul(weight),
+	}
+}
+
+// Microfacet reflection and transmission, black for smooth surfaces.
+func (m RoughDielectric) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	pair, ok := m.microfacetPair(incidentDirection, scatteredDirection, surface)
+	if !ok {
+		return color.Black
+	}
+
+	outgoing, incoming, microNormal := pair.outgoing, pair.incoming, pair.microNormal
+	dg := m.distribution.D(microNormal) * m.distribution.G(outgoing, incoming)
+	if pair.isReflection {
+		return m.color.Mul(pair.fresnel * dg / core.Abs(4*outgoing.Z()*incoming.Z()))
+	}
+
+	denominator := pair.jacobianDenominator()
+	// Without the squared index ratio of Walter et al., see the type comment
+	value := (1 - pair.fresnel) * dg *
+		core.Abs(incoming.Dot(microNormal)*outgoing.Dot(microNormal)/(outgoing.Z()*incoming.Z()*denominator))
+	return m.color.Mul(value)
+}
+
+// The density of visible normals times the probability of choosing reflection or refraction,
+// converted to scattered directions.
+func (m RoughDielectric) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	pair, ok := m.microfacetPair(incidentDirection, scatteredDirection, surface)
+	if !ok {
+		return 0
+	}
+
+	normalPDF := m.distribution.visibleNormalPDF(pair.outgoing, pair.microNormal)
+	if pair.isReflection {
+		return normalPDF * pair.fresnel / (4 * core.Abs(pair.outgoing.Dot(pair.microNormal)))
+	}
+	jacobian := core.Abs(pair.incoming.Dot(pair.microNormal)) / pair.jacobianDenominator()
+	return normalPDF * (1 - pair.fresnel) * jacobian
+}
+
+// microfacetPair describes a pair of directions in the shading frame and the microfacet normal
+// that scatters one into the other. The outgoing direction points towards the viewer,
+// the incoming one towards the light.
+type microfacetPair struct {
+	outgoing, incoming, microNormal core.Vec3
+	isReflection                    bool
+	relativeIndex                   core.Real // of the incoming side over the outgoing side
+	fresnel                         core.Real
+}
+
+// Walter et al. equation 16 divided by the squared index of the incoming side.
+func (p microfacetPair) jacobianDenominator() core.Real {
+	sum := p.incoming.Dot(p.microNormal) + p.outgoing.Dot(p.microNormal)/p.relativeIndex
+	return sum * sum
+}
+
+func (m RoughDielectric) microfacetPair(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) (microfacetPair, bool) {
+	if m.distribution.smooth() {
+		return microfacetPair{}, false
+	}
+
+	frame := newShadingFrame(surface)
+	incidentDirection = incidentDirection.Normalize()
+	outgoing := frame.toLocal(incidentDirection.Mul(-1))
+	incoming := frame.toLocal(scatteredDirection.Normalize())
+	if outgoing.Z() == 0 || incoming.Z() == 0 {
+		return microfacetPair{}, false
+	}
+
+	pair := microfacetPair{outgoing: outgoing, incoming: incoming, relativeIndex: 1}
+	pair.isReflection = outgoing.Z()*incoming.Z() > 0
+	if !pair.isReflection {
+		pair.relativeIndex = core.IfElse(outgoing.Z() > 0, m.refractor.RefractionIndex, 1/m.refractor.RefractionIndex)
+	}
+
+	// The generalized half vector, pointing to the upper side
+	microNormal := incoming.Mul(pair.relativeIndex).Add(outgoing)
+	if microNormal.LenSqr() == 0 {
+		return microfacetPair{}, false
+	}
+	microNormal = microNormal.Normalize()
+	if microNormal.Z() < 0 {
+		microNormal = microNormal.Mul(-1)
+	}
+	pair.microNormal = microNormal
+
+	// Microfacets facing away from either direction can't connect them
+	if incoming.Dot(microNormal)*incoming.Z() < 0 || outgoing.Dot(microNormal)*outgoing.Z() < 0 {
+		return microfacetPair{}, false
+	}
+
+	pair.fresnel = m.refractor.Refract(incidentDirection, frame.toWorld(microNormal)).ReflectionRatio
+	return pair, true
+}
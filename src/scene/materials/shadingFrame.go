@@ -0,0 +1,30 @@
+package materials
+
+import "github.com/Shamanskiy/go-ray-tracer/src/core"
+
+// Hittables that don't provide a tangent frame get an arbitrary one.
+func tangentFrame(surface core.SurfacePoint) (core.Vec3, core.Vec3) {
+	if surface.Tangent.LenSqr() == 0 {
+		return core.OrthonormalBasis(surface.Normal)
+	}
+	return surface.Tangent, surface.Bitangent
+}
+
+// shadingFrame converts directions between the world and the local frame of a surface,
+// where the tangent, the bitangent and the normal are the X, Y and Z axes.
+type shadingFrame struct {
+	tangent, bitangent, normal core.Vec3
+}
+
+func newShadingFrame(surface core.SurfacePoint) shadingFrame {
+	tangent, bitangent := tangentFrame(surface)
+	return shadingFrame{tangent: tangent, bitangent: bitangent, normal: surface.Normal}
+}
+
+func (f shadingFrame) toLocal(direction core.Vec3) core.Vec3 {
+	return core.NewVec3(direction.Dot(f.tangent), direction.Dot(f.bitangent), direction.Dot(f.normal))
+}
+
+func (f shadingFrame) toWorld(direction core.Vec3) core.Vec3 {
+	return f.tangent.Mul(direction.X()).Add(f.bitangent.Mul(direction.Y())).Add(f.normal.Mul(direction.Z()))
+}
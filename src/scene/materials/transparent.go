@@ -8,35 +8,46 @@ import (
 	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 )
 
+// Transparent is smooth glass. The color tints light at every interface, the absorption
+// tints light by the distance travelled inside, following the Beer-Lambert law.
 type Transparent struct {
 	color      color.Color
+	absorption color.Color // attenuation coefficient per unit distance
 	refractor  RefractionCalculator
 	randomizer random.RandomGenerator
 }
 
-func NewTransparent(refractionIndex core.Real, color color.Color, randomizer random.RandomGenerator) Transparent {
+func NewTransparent(refractionIndex core.Real, tint color.Color, randomizer random.RandomGenerator) Transparent {
+	return NewTransparentAbsorbing(refractionIndex, tint, color.Black, randomizer)
+}
+
+func NewTransparentAbsorbing(refractionIndex core.Real, tint, absorption color.Color, randomizer random.RandomGenerator) Transparent {
 	if refractionIndex < 1 {
 		panic(fmt.Errorf("refractionIndex must be at least 1, got %f", refractionIndex))
 	}
+	if absorption.R() < 0 || absorption.G() < 0 || absorption.B() < 0 {
+		panic(fmt.Errorf("absorption must be non-negative, got %v", absorption))
+	}
 	return Transparent{
-		color:      color,
+		color:      tint,
+		absorption: absorption,
 		refractor:  NewRefractionCalculator(refractionIndex),
 		randomizer: randomizer}
 }
 
-func (m Transparent) Reflect(incidentDirection, hitPoint, normalAtHitPoint core.Vec3) Reflection {
-	refraction := m.refractor.Refract(incidentDirection, normalAtHitPoint)
-	reflectedDirection := incidentDirection.Reflect(normalAtHitPoint)
+func (m Transparent) Reflect(incidentDirection core.Vec3, surface core.SurfacePoint) Reflection {
+	refraction := m.refractor.Refract(incidentDirection, surface.Normal)
+	reflectedDirection := incidentDirection.Reflect(surface.Normal)
 
 	if refraction.FullInternalReflection() {
-		return m.buildReflection(hitPoint, reflectedDirection)
+		return m.buildReflection(surface.Point, reflectedDirection)
 	}
 
 	// Transparent materials reflect a portion of the incoming light
 	if m.randomizer.Real() > refraction.ReflectionRatio {
-		return m.buildReflection(hitPoint, *refraction.Direction)
+		return m.buildReflection(surface.Point, *refraction.Direction)
 	} else {
-		return m.buildReflection(hitPoint, reflectedDirection)
+		return m.buildReflection(surface.Point, reflectedDirection)
 	}
 }
 
@@ -48,3 +59,18 @@ func (m Transparent) buildReflection(hitPoint core.Vec3, direction core.Vec3) Re
 		Color: m.color,
 	}
 }
+
+func (m Transparent) BRDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) color.Color {
+	return color.Black
+}
+
+func (m Transparent) PDF(incidentDirection, scatteredDirection core.Vec3, surface core.SurfacePoint) core.Real {
+	return 0
+}
+
+func (m Transparent) Transmittance(distance core.Real) color.Color {
+	return color.New(
+		core.Exp(-m.absorption.R()*distance),
+		core.Exp(-m.absorption.G()*distance),
+		core.Exp(-m.absorption.B()*distance))
+}
@@ -0,0 +1,92 @@
+package scene
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+)
+
+// PathTracer follows rays as they are scattered by materials until they hit
+// a light source or the background. It's the default integrator.
+type PathTracer struct {
+	maxRayReflections   int                    // prevents infinite ray bouncing between parallel walls
+	randomizer          random.RandomGenerator // picks lights and points on them
+	directLightSampling bool
+	russianRoulette     *russianRoulette
+}
+
+func NewPathTracer(settings ...PathTracerSetting) *PathTracer {
+	pathTracer := &PathTracer{
+		maxRayReflections: DEFAULT_MAX_RAY_REFLECTIONS,
+	}
+	for _, setting := range settings {
+		setting(pathTracer)
+	}
+	return pathTracer
+}
+
+func (p *PathTracer) Integrate(ray core.Ray, scene *SceneImpl) color.Color {
+	return p.testRay(scene, ray, 0, 0, color.White, nil)
+}
+
+// With direct light sampling, light reaching a surface straight from a light source is found
+// both by light sampling and by scattered rays hitting the light. The scattering PDF of the ray
+// is used to weight the two against each other, it's zero for camera rays and discrete scattering.
+// The throughput is the fraction of the ray color that reaches the camera.
+// Light travelling inside absorbing dielectrics is attenuated by the distance to the hit.
+func (p *PathTracer) testRay(scene *SceneImpl, ray core.Ray, reflectionDepth int, scatteringPDF core.Real,
+	throughput color.Color, interior interior) color.Color {
+	optionalHit := scene.Intersect(ray)
+	if optionalHit.Empty() {
+		return scene.BackgroundColor(ray)
+	}
+
+	if reflectionDepth >= p.maxRayReflections {
+		return color.Black
+	}
+
+	hit := optionalHit.Value()
+	transmittance := interior.transmittance(ray, hit)
+	return p.shade(scene, ray, hit, reflectionDepth, scatteringPDF, throughput.MulColor(transmittance), interior).
+		MulColor(transmittance)
+}
+
+func (p *PathTracer) shade(scene *SceneImpl, ray core.Ray, hit geometries.Hit, reflectionDepth int, scatteringPDF core.Real,
+	throughput color.Color, interior interior) color.Color {
+	reflection := reflect(ray, hit)
+	switch reflection.Type {
+	case materials.Scattered:
+		return p.scatter(scene, ray, hit, reflection, reflectionDepth, throughput, interior.update(ray, hit, reflection))
+	case materials.Emitted:
+		if !p.directLightSampling {
+			return reflection.Color
+		}
+		return reflection.Color.Mul(scene.emissionWeight(ray, hit, scatteringPDF))
+	case materials.Absorbed:
+		return color.Black
+	default:
+		panic("unknown reflection type")
+	}
+}
+
+func (p *PathTracer) scatter(scene *SceneImpl, ray core.Ray, hit geometries.Hit, reflection materials.Reflection,
+	reflectionDepth int, throughput color.Color, interior interior) color.Color {
+	directLight := color.Black
+	reflectedPDF := core.Real(0)
+	if p.directLightSampling {
+		directLight = scene.sampleDirectLight(ray, hit, p.randomizer)
+		reflectedPDF = hit.Material.PDF(ray.Direction(), reflection.Ray.Direction(), hit.SurfacePoint)
+	}
+
+	throughput = throughput.MulColor(reflection.Color)
+	survivalProbability := p.survivalProbability(reflectionDepth+1, throughput)
+	if survivalProbability < 1 && p.russianRoulette.randomizer.Real() >= survivalProbability {
+		return directLight
+	}
+
+	// Surviving rays compensate for the terminated ones, so the estimate stays unbiased
+	reflectedRayColor := p.testRay(scene, reflection.Ray, reflectionDepth+1, reflectedPDF, throughput.Div(survivalProbability), interior)
+	return directLight.Add(reflectedRayColor.MulColor(reflection.Color).Div(survivalProbability))
+}
@@ -0,0 +1,49 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+type PathTracerSetting func(*PathTracer)
+
+func PathTracerMaxRayReflections(maxReflections int) PathTracerSetting {
+	if maxReflections < 0 {
+		panic(fmt.Errorf("invalid max ray reflections: %d", maxReflections))
+	}
+	return func(pathTracer *PathTracer) {
+		pathTracer.maxRayReflections = maxReflections
+	}
+}
+
+// PathTracerDirectLightSampling enables next event estimation: at every diffuse or glossy hit, a shadow ray is
+// traced towards a random point on a light source. Light sampling and material sampling are combined
+// with multiple importance sampling. Light sources are objects with emitting materials, only those
+// with geometries implementing geometries.Sampleable are sampled.
+func PathTracerDirectLightSampling(randomizer random.RandomGenerator) PathTracerSetting {
+	return func(pathTracer *PathTracer) {
+		pathTracer.randomizer = randomizer
+		pathTracer.directLightSampling = true
+	}
+}
+
+// PathTracerRussianRoulette randomly terminates rays starting from the given reflection depth. The survival
+// probability follows the ray throughput, so dim rays are terminated early, but it never drops
+// below the given minimum. The max ray reflections still apply.
+func PathTracerRussianRoulette(startDepth int, minSurvivalProbability core.Real, randomizer random.RandomGenerator) PathTracerSetting {
+	if startDepth < 0 {
+		panic(fmt.Errorf("invalid russian roulette start depth: %d", startDepth))
+	}
+	if minSurvivalProbability <= 0 || minSurvivalProbability > 1 {
+		panic(fmt.Errorf("invalid russian roulette min survival probability: %v", minSurvivalProbability))
+	}
+	return func(pathTracer *PathTracer) {
+		pathTracer.russianRoulette = &russianRoulette{
+			startDepth:             startDepth,
+			minSurvivalProbability: minSurvivalProbability,
+			randomizer:             randomizer,
+		}
+	}
+}
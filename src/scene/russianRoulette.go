@@ -0,0 +1,22 @@
+package scene
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+)
+
+type russianRoulette struct {
+	startDepth             int
+	minSurvivalProbability core.Real
+	randomizer             random.RandomGenerator
+}
+
+// https://www.pbr-book.org/3ed-2018/Light_Transport_I_Surface_Reflection/Path_Tracing#ImplementationofPathTracing
+func (p *PathTracer) survivalProbability(reflectionDepth int, throughput color.Color) core.Real {
+	if p.russianRoulette == nil || reflectionDepth < p.russianRoulette.startDepth {
+		return 1
+	}
+	probability := core.Max(throughput.MaxComponent(), p.russianRoulette.minSurvivalProbability)
+	return core.Min(probability, 1)
+}
@@ -3,9 +3,9 @@ package scene
 import (
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/optional"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/background"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
-	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
 )
 
 const (
@@ -13,59 +13,88 @@ const (
 	DEFAULT_MAX_RAY_REFLECTIONS int       = 10
 )
 
+// SceneImpl holds the objects and their BVH, the colors of rays are computed by its integrator.
 type SceneImpl struct {
 	background background.Background
-	bvh        *geometries.BVHNode
+	bvh        *geometries.LinearBVH
+	media      []Object // participating media, kept out of the BVH so that they attenuate light rather than block it
+	lights     []light
+	integrator Integrator
+	fog        *boundedFog
 
-	minHitParam       core.Real // prevents black acne
-	maxRayReflections int       // prevents infinite ray bouncing between parallel walls
+	minHitParam        core.Real // prevents black acne
+	bvhSettings        []geometries.BVHSetting
+	pathTracerSettings []PathTracerSetting // of the default integrator
 }
 
 func New(objects []Object, background background.Background, settings ...SceneImplSetting) *SceneImpl {
 	scene := &SceneImpl{
-		background:        background,
-		minHitParam:       DEFAULT_MIN_HIT_PARAM,
-		maxRayReflections: DEFAULT_MAX_RAY_REFLECTIONS,
+		background:  background,
+		minHitParam: DEFAULT_MIN_HIT_PARAM,
 	}
 
 	for _, setting := range settings {
 		setting(scene)
 	}
+	if scene.integrator == nil {
+		scene.integrator = NewPathTracer(scene.pathTracerSettings...)
+	}
 
 	hittables := make([]geometries.Hittable, 0, len(objects))
 	for _, object := range objects {
-		hittables = append(hittables, object)
+		if _, isMedium := object.Hittable.(geometries.Medium); isMedium {
+			scene.media = append(scene.media, object)
+		} else {
+			hittables = append(hittables, object)
+		}
+	}
+	if scene.fog != nil {
+		if fog, ok := scene.fog.object(finiteBoundingBox(objects)); ok {
+			scene.media = append(scene.media, fog)
+		}
 	}
-	scene.bvh = geometries.BuildBVH(hittables)
+	scene.bvh = geometries.NewLinearBVH(hittables, scene.bvhSettings...)
+	scene.lights = collectLights(objects)
 
 	return scene
 }
 
+func (s *SceneImpl) BVHStats() geometries.BVHStats {
+	return s.bvh.Stats()
+}
+
 func (s *SceneImpl) TestRay(ray core.Ray) color.Color {
-	return s.testRay(ray, 0)
+	return s.integrator.Integrate(ray, s)
 }
 
-func (s *SceneImpl) testRay(ray core.Ray, reflectionDepth int) color.Color {
-	optionalHit := s.bvh.TestRay(ray, core.NewInterval(s.minHitParam, core.Inf()))
-	if optionalHit.Empty() {
-		return s.background.ColorRay(ray)
+// Intersect returns the closest hit of the ray, ignoring hits too close to the ray origin.
+// In participating media, including the fog, the ray may scatter before reaching the closest surface.
+func (s *SceneImpl) Intersect(ray core.Ray) optional.Optional[geometries.Hit] {
+	params := core.NewInterval(s.minHitParam, core.Inf())
+	hit := s.bvh.TestRay(ray, params)
+	for _, medium := range s.media {
+		end := params.Max()
+		if hit.Present() {
+			end = hit.Value().Param
+		}
+		if mediumHit := medium.TestRay(ray, core.NewInterval(params.Min(), end)); mediumHit.Present() {
+			hit = mediumHit
+		}
 	}
+	return hit
+}
 
-	if reflectionDepth >= s.maxRayReflections {
-		return color.Black
+// Unbounded objects, like planes, are left out, so that the fog around them stays finite.
+func finiteBoundingBox(objects []Object) core.Box {
+	box := core.NewEmptyBox()
+	for _, object := range objects {
+		if objectBox := object.BoundingBox(); objectBox.Finite() {
+			box = box.Union(objectBox)
+		}
 	}
+	return box
+}
 
-	hit := optionalHit.Value()
-	reflection := hit.Material.Reflect(ray.Direction(), hit.Point, hit.Normal)
-	switch reflection.Type {
-	case materials.Scattered:
-		reflectedRayColor := s.testRay(reflection.Ray, reflectionDepth+1)
-		return reflectedRayColor.MulColor(reflection.Color)
-	case materials.Emitted:
-		return reflection.Color
-	case materials.Absorbed:
-		return color.Black
-	default:
-		panic("unknown reflection type")
-	}
+func (s *SceneImpl) BackgroundColor(ray core.Ray) color.Color {
+	return s.background.ColorRay(ray)
 }
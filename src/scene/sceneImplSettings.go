@@ -4,6 +4,9 @@ import (
 	"fmt"
 
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
 )
 
 type SceneImplSetting func(*SceneImpl)
@@ -17,11 +20,49 @@ func MinRayHitParameter(minHitParam core.Real) SceneImplSetting {
 	}
 }
 
+func BVHSplitMethod(method geometries.SplitMethod) SceneImplSetting {
+	bvhSetting := geometries.BVHSplitMethod(method)
+	return func(scene *SceneImpl) {
+		scene.bvhSettings = append(scene.bvhSettings, bvhSetting)
+	}
+}
+
+// MaxRayReflections, DirectLightSampling and RussianRoulette configure the default path tracer,
+// see the corresponding PathTracerSettings. They don't apply to integrators set with UseIntegrator.
 func MaxRayReflections(maxReflections int) SceneImplSetting {
-	if maxReflections < 0 {
-		panic(fmt.Errorf("invalid max ray reflections: %d", maxReflections))
+	return withPathTracerSetting(PathTracerMaxRayReflections(maxReflections))
+}
+
+func DirectLightSampling(randomizer random.RandomGenerator) SceneImplSetting {
+	return withPathTracerSetting(PathTracerDirectLightSampling(randomizer))
+}
+
+func RussianRoulette(startDepth int, minSurvivalProbability core.Real, randomizer random.RandomGenerator) SceneImplSetting {
+	return withPathTracerSetting(PathTracerRussianRoulette(startDepth, minSurvivalProbability, randomizer))
+}
+
+func withPathTracerSetting(pathTracerSetting PathTracerSetting) SceneImplSetting {
+	return func(scene *SceneImpl) {
+		scene.pathTracerSettings = append(scene.pathTracerSettings, pathTracerSetting)
+	}
+}
+
+// UseIntegrator replaces the default path tracer.
+func UseIntegrator(integrator Integrator) SceneImplSetting {
+	if integrator == nil {
+		panic(fmt.Errorf("invalid integrator: nil"))
 	}
 	return func(scene *SceneImpl) {
-		scene.maxRayReflections = maxReflections
+		scene.integrator = integrator
+	}
+}
+
+// BoundedFog fills the bounding box of the finite scene objects with a participating medium of constant density.
+// Only the parts of rays inside the box are in the fog, the background around the scene stays clear.
+// The phase function defines how the fog scatters light and its albedo.
+func BoundedFog(density core.Real, phaseFunction materials.PhaseFunction, randomizer random.RandomGenerator) SceneImplSetting {
+	medium := geometries.NewConstantDensity(density, randomizer)
+	return func(scene *SceneImpl) {
+		scene.fog = &boundedFog{medium: medium, phaseFunction: phaseFunction}
 	}
 }
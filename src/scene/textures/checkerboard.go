@@ -0,0 +1,30 @@
+package textures
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+)
+
+// Checkerboard alternates two textures in UV space, with the given number of squares per UV unit.
+type Checkerboard struct {
+	even, odd Texture
+	frequency core.Real
+}
+
+func NewCheckerboard(even, odd Texture, frequency core.Real) Checkerboard {
+	if frequency <= 0 {
+		panic(fmt.Errorf("invalid checkerboard frequency: %v", frequency))
+	}
+	return Checkerboard{even: even, odd: odd, frequency: frequency}
+}
+
+func (c Checkerboard) ColorAt(surface core.SurfacePoint) color.Color {
+	column := int(core.Floor(surface.UV.X() * c.frequency))
+	row := int(core.Floor(surface.UV.Y() * c.frequency))
+	if (column+row)%2 == 0 {
+		return c.even.ColorAt(surface)
+	}
+	return c.odd.ColorAt(surface)
+}
@@ -0,0 +1,23 @@
+package textures
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+)
+
+type Constant struct {
+	color color.Color
+}
+
+func NewConstant(color color.Color) Constant {
+	return Constant{color: color}
+}
+
+func (c Constant) ColorAt(surface core.SurfacePoint) color.Color {
+	return c.color
+}
+
+// NewScalar returns a gray constant, for material parameters that are single numbers.
+func NewScalar(value core.Real) Constant {
+	return NewConstant(color.New(value, value, value))
+}
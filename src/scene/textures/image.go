@@ -0,0 +1,130 @@
+package textures
+
+import (
+	"fmt"
+	"image"
+	rgba "image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+)
+
+// WrapMode defines how texture coordinates outside of [0, 1] are mapped to the image.
+type WrapMode int
+
+const (
+	WrapRepeat WrapMode = iota
+	WrapClamp
+	WrapMirror
+)
+
+// Image maps UV coordinates to an image with bilinear filtering. U goes from left to right,
+// V from the bottom to the top of the image.
+type Image struct {
+	width, height int
+	pixels        []color.Color // row by row from the top
+	wrapMode      WrapMode
+}
+
+func LoadImageFile(filename string, wrapMode WrapMode) (*Image, error) {
+	decoded, err := decodeImageFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewImage(decoded, wrapMode), nil
+}
+
+// LoadLinearImageFile loads an image without gamma correction, see NewLinearImage.
+func LoadLinearImageFile(filename string, wrapMode WrapMode) (*Image, error) {
+	decoded, err := decodeImageFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewLinearImage(decoded, wrapMode), nil
+}
+
+func decodeImageFile(filename string) (image.Image, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("load image texture: %w", err)
+	}
+	defer file.Close()
+
+	decoded, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("load image texture %s: %w", filename, err)
+	}
+	return decoded, nil
+}
+
+// NewImage converts image pixels to linear colors, undoing the gamma correction of color.ToRGBA.
+func NewImage(img image.Image, wrapMode WrapMode) *Image {
+	return newImage(img, wrapMode, color.FromRGBA)
+}
+
+// NewLinearImage keeps pixel values as they are, which suits data textures like normal maps.
+func NewLinearImage(img image.Image, wrapMode WrapMode) *Image {
+	return newImage(img, wrapMode, color.FromRGBALinear)
+}
+
+func newImage(img image.Image, wrapMode WrapMode, convert func(rgba.Color) color.Color) *Image {
+	if wrapMode != WrapRepeat && wrapMode != WrapClamp && wrapMode != WrapMirror {
+		panic(fmt.Errorf("invalid texture wrap mode: %d", wrapMode))
+	}
+
+	bounds := img.Bounds()
+	texture := &Image{
+		width:    bounds.Dx(),
+		height:   bounds.Dy(),
+		pixels:   make([]color.Color, 0, bounds.Dx()*bounds.Dy()),
+		wrapMode: wrapMode,
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			texture.pixels = append(texture.pixels, convert(img.At(x, y)))
+		}
+	}
+	return texture
+}
+
+func (t *Image) ColorAt(surface core.SurfacePoint) color.Color {
+	if len(t.pixels) == 0 {
+		return color.Black
+	}
+
+	// Pixel centers lie at half-integer coordinates
+	x := surface.UV.X()*core.Real(t.width) - 0.5
+	y := (1-surface.UV.Y())*core.Real(t.height) - 0.5
+	x0, y0 := core.Floor(x), core.Floor(y)
+	tx, ty := x-x0, y-y0
+
+	column, row := int(x0), int(y0)
+	top := color.Interpolate(t.pixel(column, row), t.pixel(column+1, row), tx)
+	bottom := color.Interpolate(t.pixel(column, row+1), t.pixel(column+1, row+1), tx)
+	return color.Interpolate(top, bottom, ty)
+}
+
+func (t *Image) pixel(column, row int) color.Color {
+	column = wrap(column, t.width, t.wrapMode)
+	row = wrap(row, t.height, t.wrapMode)
+	return t.pixels[row*t.width+column]
+}
+
+func wrap(index, size int, wrapMode WrapMode) int {
+	switch wrapMode {
+	case WrapClamp:
+		return core.IfElse(index < 0, 0, core.IfElse(index >= size, size-1, index))
+	case WrapMirror:
+		period := positiveModulo(index, 2*size)
+		return core.IfElse(period < size, period, 2*size-1-period)
+	default:
+		return positiveModulo(index, size)
+	}
+}
+
+func positiveModulo(index, size int) int {
+	return (index%size + size) % size
+}
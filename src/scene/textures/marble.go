@@ -0,0 +1,29 @@
+package textures
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/noise"
+)
+
+// Marble has veins running across the X axis, a sine wave distorted by turbulence.
+// Scale is the vein frequency, turbulence is the strength of the distortion.
+type Marble struct {
+	perlin      *noise.Perlin
+	scale       core.Real
+	turbulence  core.Real
+	base, veins color.Color
+}
+
+func NewMarble(perlin *noise.Perlin, scale, turbulence core.Real, base, veins color.Color) Marble {
+	validateProcedural(perlin, scale)
+	validateTurbulence(turbulence)
+	return Marble{perlin: perlin, scale: scale, turbulence: turbulence, base: base, veins: veins}
+}
+
+func (m Marble) ColorAt(surface core.SurfacePoint) color.Color {
+	point := surface.Point.Mul(m.scale)
+	phase := point.X() + m.turbulence*m.perlin.Turbulence(point, DEFAULT_NOISE_OCTAVES)
+	// Veins are the narrow valleys of the sine wave
+	return color.Interpolate(m.veins, m.base, core.Abs(core.Sin(phase)))
+}
@@ -0,0 +1,48 @@
+package textures
+
+import (
+	"fmt"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/noise"
+)
+
+const DEFAULT_NOISE_OCTAVES = 7
+
+// Noise blends two colors with fractal noise of the hit point.
+// Scale is the noise frequency, the number of lattice cells per world unit.
+type Noise struct {
+	perlin    *noise.Perlin
+	scale     core.Real
+	low, high color.Color
+}
+
+func NewNoise(perlin *noise.Perlin, scale core.Real, low, high color.Color) Noise {
+	validateProcedural(perlin, scale)
+	return Noise{perlin: perlin, scale: scale, low: low, high: high}
+}
+
+func (n Noise) ColorAt(surface core.SurfacePoint) color.Color {
+	value := n.perlin.FBM(surface.Point.Mul(n.scale), DEFAULT_NOISE_OCTAVES)
+	return color.Interpolate(n.low, n.high, clampUnit(0.5*(value+1)))
+}
+
+func validateProcedural(perlin *noise.Perlin, scale core.Real) {
+	if perlin == nil {
+		panic(fmt.Errorf("procedural texture needs a noise generator"))
+	}
+	if scale <= 0 {
+		panic(fmt.Errorf("invalid procedural texture scale: %v", scale))
+	}
+}
+
+func validateTurbulence(turbulence core.Real) {
+	if turbulence < 0 {
+		panic(fmt.Errorf("invalid procedural texture turbulence: %v", turbulence))
+	}
+}
+
+func clampUnit(value core.Real) core.Real {
+	return core.Max(0, core.Min(1, value))
+}
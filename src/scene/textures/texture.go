@@ -0,0 +1,10 @@
+package textures
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+)
+
+type Texture interface {
+	ColorAt(surface core.SurfacePoint) color.Color
+}
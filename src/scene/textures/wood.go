@@ -0,0 +1,29 @@
+package textures
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/noise"
+)
+
+// Wood has growth rings around the Y axis, perturbed by noise.
+// Scale is the number of rings per world unit, turbulence is the strength of the perturbation.
+type Wood struct {
+	perlin      *noise.Perlin
+	scale       core.Real
+	turbulence  core.Real
+	light, dark color.Color
+}
+
+func NewWood(perlin *noise.Perlin, scale, turbulence core.Real, light, dark color.Color) Wood {
+	validateProcedural(perlin, scale)
+	validateTurbulence(turbulence)
+	return Wood{perlin: perlin, scale: scale, turbulence: turbulence, light: light, dark: dark}
+}
+
+func (w Wood) ColorAt(surface core.SurfacePoint) color.Color {
+	point := surface.Point.Mul(w.scale)
+	radius := core.Hypot(point.X(), point.Z()) + w.turbulence*w.perlin.FBM(point, DEFAULT_NOISE_OCTAVES)
+	ring := radius - core.Floor(radius)
+	return color.Interpolate(w.light, w.dark, ring)
+}
@@ -63,6 +63,14 @@ func TestCamera_ShouldMultiSampleEachPixel(t *testing.T) {
 	assert.Len(t, scene.RecordedRays, 10)
 }
 
+func TestCamera_ShouldPanic_WhenShutterClosesBeforeOpening(t *testing.T) {
+	settings := cameraSettings
+	settings.ShutterOpen = 1
+	settings.ShutterClose = 0.5
+
+	assert.Panics(t, func() { camera.NewCamera(&settings, randomizer) })
+}
+
 func assertAllPixelsColor(t *testing.T, image *image.Image, color color.Color) {
 	for x := 0; x < image.Width(); x++ {
 		for y := 0; y < image.Height(); y++ {
@@ -51,3 +51,31 @@ func TestRayGenerator_RayWithDefocusBlurShouldFocusOnFocusPlane(t *testing.T) {
 		assert.NotEqual(t, LOOK_FROM, ray.Origin())
 	}
 }
+
+func TestRayGenerator_ShouldSampleTimeWithinShutterInterval(t *testing.T) {
+	settings := *CAMERA_SETTINGS
+	settings.ShutterOpen = 1
+	settings.ShutterClose = 2
+	rayGenerator := camera.NewRayGenerator(&settings, random.NewRandomGenerator())
+
+	minTime, maxTime := core.Inf(), -core.Inf()
+	for i := 0; i < 100; i++ {
+		time := rayGenerator.GenerateRay(0.5, 0.5).Time()
+		minTime = core.Min(minTime, time)
+		maxTime = core.Max(maxTime, time)
+	}
+
+	assert.GreaterOrEqual(t, minTime, core.Real(1))
+	assert.LessOrEqual(t, maxTime, core.Real(2))
+	assert.Less(t, maxTime-minTime, core.Real(1))
+	assert.Greater(t, maxTime-minTime, core.Real(0.5))
+}
+
+func TestRayGenerator_ShouldTraceAtShutterOpen_WhenShutterIsInstant(t *testing.T) {
+	settings := *CAMERA_SETTINGS
+	settings.ShutterOpen = 3
+	settings.ShutterClose = 3
+	rayGenerator := camera.NewRayGenerator(&settings, random.NewRandomGenerator())
+
+	assert.Equal(t, core.Real(3), rayGenerator.GenerateRay(0.5, 0.5).Time())
+}
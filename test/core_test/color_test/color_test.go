@@ -24,3 +24,18 @@ func TestColor_GrayToRGBA(t *testing.T) {
 func TestColor_Interpolate(t *testing.T) {
 	assert.Equal(t, color.GrayMedium, color.Interpolate(color.Black, color.White, 0.5))
 }
+
+func TestColor_MaxComponent(t *testing.T) {
+	assert.EqualValues(t, 1, color.SkyBlue.MaxComponent())
+	assert.EqualValues(t, 0.5, color.New(0.25, 0.5, 0.125).MaxComponent())
+}
+
+func TestColor_FromRGBA_ShouldUndoGammaCorrection(t *testing.T) {
+	assert.Equal(t, color.Black, color.FromRGBA(rgba.RGBA{0, 0, 0, 255}))
+	assert.Equal(t, color.White, color.FromRGBA(rgba.RGBA{255, 255, 255, 255}))
+	assert.InDelta(t, 0.25, color.FromRGBA(rgba.RGBA{128, 128, 128, 255}).R(), 0.01)
+}
+
+func TestColor_FromRGBALinear_ShouldKeepChannels(t *testing.T) {
+	assert.InDelta(t, 0.5, color.FromRGBALinear(rgba.RGBA{128, 128, 128, 255}).R(), 0.01)
+}
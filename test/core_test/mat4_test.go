@@ -0,0 +1,118 @@
+package core_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMat4_ShouldTranslatePointButNotDirection(t *testing.T) {
+	translation := core.Translation(core.NewVec3(1, 2, 3))
+
+	assert.Equal(t, core.NewVec3(2, 3, 4), translation.TransformPoint(core.NewVec3(1, 1, 1)))
+	assert.Equal(t, core.NewVec3(1, 1, 1), translation.TransformDirection(core.NewVec3(1, 1, 1)))
+}
+
+func TestMat4_ShouldRotateAroundAxis(t *testing.T) {
+	rotation := core.Rotation(90, core.NewVec3(0, 0, 2))
+
+	result := rotation.TransformPoint(core.NewVec3(1, 0, 0))
+
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 1, 0), result, core.Tolerance)
+}
+
+func TestMat4_ShouldApplyRightFactorFirst(t *testing.T) {
+	transform := core.Translation(core.NewVec3(1, 0, 0)).Mul(core.Scaling(core.NewVec3(2, 2, 2)))
+
+	assert.Equal(t, core.NewVec3(3, 2, 2), transform.TransformPoint(core.NewVec3(1, 1, 1)))
+}
+
+func TestMat4_InverseShouldUndoTransform(t *testing.T) {
+	transform := core.Translation(core.NewVec3(1, -2, 3)).Mul(core.Rotation(30, core.NewVec3(1, 1, 0)))
+	point := core.NewVec3(4, 5, 6)
+
+	result := transform.Inverse().TransformPoint(transform.TransformPoint(point))
+
+	test.AssertInDeltaVec3(t, point, result, core.Tolerance)
+}
+
+func TestMat4_ShouldNotBeInvertible_IfScalingByZero(t *testing.T) {
+	assert.False(t, core.Scaling(core.NewVec3(1, 0, 1)).Invertible())
+	assert.True(t, core.Scaling(core.NewVec3(1, 2, 1)).Invertible())
+}
+
+func TestMat4_ShouldTransformBox(t *testing.T) {
+	box := core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(2, 1, 1))
+	transform := core.Translation(core.NewVec3(0, 0, 5)).Mul(core.Rotation(90, core.NewVec3(0, 0, 1)))
+
+	result := transform.TransformBox(box)
+
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 0, 5), result.Min(), core.Tolerance)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 2, 6), result.Max(), core.Tolerance)
+}
+
+func TestMat4_ShouldKeepInfiniteBoxInfinite(t *testing.T) {
+	transform := core.Translation(core.NewVec3(1, 2, 3))
+
+	result := transform.TransformBox(core.NewInfiniteBox())
+
+	assert.Equal(t, core.NewInfiniteBox(), result)
+}
+
+func TestMat4_ShouldKeepEmptyBoxEmpty(t *testing.T) {
+	transform := core.Rotation(45, core.NewVec3(1, 0, 0))
+
+	result := transform.TransformBox(core.NewEmptyBox())
+
+	assert.True(t, result.Empty())
+}
+
+func TestMat4_InterpolateShouldBlendTranslationRotationAndScaling(t *testing.T) {
+	start, _ := core.Translation(core.NewVec3(2, 0, 0)).Decompose()
+	end, _ := core.Translation(core.NewVec3(4, 2, 0)).Mul(core.Rotation(90, core.NewVec3(0, 0, 1))).Mul(core.Scaling(core.NewVec3(3, 3, 3))).Decompose()
+
+	halfway, _ := start.Interpolate(end, 0.5).Matrices()
+
+	expected := core.Translation(core.NewVec3(3, 1, 0)).Mul(core.Rotation(45, core.NewVec3(0, 0, 1))).Mul(core.Scaling(core.NewVec3(2, 2, 2)))
+	point := core.NewVec3(1, 2, 3)
+	test.AssertInDeltaVec3(t, expected.TransformPoint(point), halfway.TransformPoint(point), 1e-5)
+	assert.InDelta(t, core.Real(math.Pi/2), start.RotationAngle(end), 1e-5)
+}
+
+func TestMat4_InterpolateShouldReturnEndPoints(t *testing.T) {
+	start := core.Scaling(core.NewVec3(-1, 2, 1))
+	end := core.Translation(core.NewVec3(1, 2, 3)).Mul(core.Rotation(200, core.NewVec3(1, 1, 0)))
+	startParts, _ := start.Decompose()
+	endParts, _ := end.Decompose()
+	point := core.NewVec3(1, 2, 3)
+
+	atStart, _ := startParts.Interpolate(endParts, 0).Matrices()
+	atEnd, _ := startParts.Interpolate(endParts, 1).Matrices()
+
+	test.AssertInDeltaVec3(t, start.TransformPoint(point), atStart.TransformPoint(point), 1e-5)
+	test.AssertInDeltaVec3(t, end.TransformPoint(point), atEnd.TransformPoint(point), 1e-5)
+}
+
+func TestMat4_DecompositionShouldComposeInverse(t *testing.T) {
+	matrix := core.Translation(core.NewVec3(1, -2, 3)).Mul(core.Rotation(30, core.NewVec3(1, 2, 0))).Mul(core.Scaling(core.NewVec3(-2, 3, 0.5)))
+	parts, ok := matrix.Decompose()
+
+	transform, inverse := parts.Matrices()
+
+	assert.True(t, ok)
+	point := core.NewVec3(1, 2, 3)
+	test.AssertInDeltaVec3(t, matrix.TransformPoint(point), transform.TransformPoint(point), 1e-5)
+	test.AssertInDeltaVec3(t, point, inverse.TransformPoint(transform.TransformPoint(point)), 1e-5)
+}
+
+func TestMat4_DecomposeShouldFail_IfMatrixHasShear(t *testing.T) {
+	// Scaling along a diagonal after a rotation shears the axes
+	shear := core.Rotation(45, core.NewVec3(0, 0, 1)).Mul(core.Scaling(core.NewVec3(2, 1, 1))).Mul(core.Rotation(-30, core.NewVec3(0, 0, 1)))
+
+	_, ok := shear.Decompose()
+
+	assert.False(t, ok)
+}
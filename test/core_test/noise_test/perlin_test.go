@@ -0,0 +1,83 @@
+package noise_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/noise"
+	"github.com/stretchr/testify/assert"
+)
+
+const SEED = 42
+
+func TestPerlin_ShouldBeDeterministicForSameSeed(t *testing.T) {
+	first, second := noise.NewPerlin(SEED), noise.NewPerlin(SEED)
+
+	for _, point := range samplePoints() {
+		assert.Equal(t, first.Noise(point), second.Noise(point))
+	}
+}
+
+func TestPerlin_ShouldDifferForDifferentSeeds(t *testing.T) {
+	first, second := noise.NewPerlin(SEED), noise.NewPerlin(SEED+1)
+
+	differences := 0
+	for _, point := range samplePoints() {
+		if first.Noise(point) != second.Noise(point) {
+			differences++
+		}
+	}
+	assert.Greater(t, differences, len(samplePoints())/2)
+}
+
+func TestPerlin_ShouldBeZeroAtLatticePoints(t *testing.T) {
+	perlin := noise.NewPerlin(SEED)
+
+	assert.EqualValues(t, 0, perlin.Noise(core.NewVec3(0, 0, 0)))
+	assert.EqualValues(t, 0, perlin.Noise(core.NewVec3(3, -7, 12)))
+}
+
+func TestPerlin_ShouldBeContinuous(t *testing.T) {
+	perlin := noise.NewPerlin(SEED)
+	step := core.NewVec3(1e-3, 1e-3, 1e-3)
+
+	for _, point := range samplePoints() {
+		assert.InDelta(t, perlin.Noise(point), perlin.Noise(point.Add(step)), 1e-2)
+	}
+}
+
+func TestPerlin_ShouldStayInRange(t *testing.T) {
+	perlin := noise.NewPerlin(SEED)
+
+	for _, point := range samplePoints() {
+		assert.LessOrEqual(t, core.Abs(perlin.Noise(point)), core.Real(1))
+		assert.LessOrEqual(t, core.Abs(perlin.FBM(point, 5)), core.Real(1))
+		turbulence := perlin.Turbulence(point, 5)
+		assert.GreaterOrEqual(t, turbulence, core.Real(0))
+		assert.LessOrEqual(t, turbulence, core.Real(1))
+	}
+}
+
+func TestPerlin_FBMWithOneOctaveShouldMatchNoise(t *testing.T) {
+	perlin := noise.NewPerlin(SEED)
+
+	for _, point := range samplePoints() {
+		assert.Equal(t, perlin.Noise(point), perlin.FBM(point, 1))
+	}
+}
+
+func TestPerlin_ShouldPanic_IfNoOctaves(t *testing.T) {
+	perlin := noise.NewPerlin(SEED)
+
+	assert.Panics(t, func() { perlin.FBM(core.NewVec3(0.5, 0.5, 0.5), 0) })
+	assert.Panics(t, func() { perlin.Turbulence(core.NewVec3(0.5, 0.5, 0.5), -1) })
+}
+
+func samplePoints() []core.Vec3 {
+	points := []core.Vec3{}
+	for i := 0; i < 200; i++ {
+		x := core.Real(i)
+		points = append(points, core.NewVec3(0.37*x-20, 0.91*x+0.13, -0.53*x+7.7))
+	}
+	return points
+}
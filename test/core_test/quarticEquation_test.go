@@ -0,0 +1,53 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuarticEquation_ShouldHaveFourSolutions(t *testing.T) {
+	// (x - 1)(x - 2)(x - 3)(x - 4)
+	solution := core.SolveQuarticEquation(2, -20, 70, -100, 48)
+
+	assert.Equal(t, 4, solution.Count)
+	for i, expected := range []core.Real{1, 2, 3, 4} {
+		assert.InDelta(t, expected, solution.Roots[i], 1e-5)
+	}
+}
+
+func TestQuarticEquation_ShouldHaveTwoSolutions(t *testing.T) {
+	// (x^2 + 1)(x - 1)(x + 2)
+	solution := core.SolveQuarticEquation(1, 1, -1, 1, -2)
+
+	assert.Equal(t, 2, solution.Count)
+	assert.InDelta(t, -2, solution.Roots[0], 1e-5)
+	assert.InDelta(t, 1, solution.Roots[1], 1e-5)
+}
+
+func TestQuarticEquation_ShouldSolveBiquadraticEquation(t *testing.T) {
+	// (x^2 - 1)(x^2 - 4)
+	solution := core.SolveQuarticEquation(1, 0, -5, 0, 4)
+
+	assert.Equal(t, 4, solution.Count)
+	for i, expected := range []core.Real{-2, -1, 1, 2} {
+		assert.InDelta(t, expected, solution.Roots[i], 1e-5)
+	}
+}
+
+func TestQuarticEquation_ShouldFindRootsWithLargeSpread(t *testing.T) {
+	// (x - 0.01)(x - 0.02)(x - 100)(x - 200), like a ray hitting a small distant torus
+	solution := core.SolveQuarticEquation(1, -300.03, 20009.0002, -600.06, 4)
+
+	assert.Equal(t, 4, solution.Count)
+	for i, expected := range []core.Real{0.01, 0.02, 100, 200} {
+		assert.InDelta(t, expected, solution.Roots[i], float64(1e-4*expected))
+	}
+}
+
+func TestQuarticEquation_ShouldHaveNoSolutions(t *testing.T) {
+	solution := core.SolveQuarticEquation(1, 0, 2, 0, 5)
+
+	assert.Equal(t, 0, solution.Count)
+}
@@ -20,6 +20,13 @@ func TestRandomReal_ShouldBeBetweenZeroAndOne(t *testing.T) {
 	}
 }
 
+func TestUnitReal_ShouldStayBelowOne_WhenBitsRoundUp(t *testing.T) {
+	test.AssertInSemiInternal(t, random.UnitReal(math.MaxUint64), 0, 1)
+	test.AssertInSemiInternal(t, random.UnitReal(math.MaxUint64-1<<32), 0, 1)
+	assert.Equal(t, core.Real(0), random.UnitReal(0))
+	assert.Equal(t, core.Real(0.5), random.UnitReal(1<<63))
+}
+
 func TestRandomReal_ShouldBeApproximatelyUniform(t *testing.T) {
 	randomGenerator := random.NewRandomGenerator()
 	valueCounts := make(map[int]int)
@@ -30,7 +37,7 @@ func TestRandomReal_ShouldBeApproximatelyUniform(t *testing.T) {
 
 	for i := 0; i < numSamples; i++ {
 		randomReal := randomGenerator.Real()
-		valueCounts[int(randomReal*float32(numBins))]++
+		valueCounts[int(randomReal*core.Real(numBins))]++
 	}
 
 	for bin := 0; bin < numBins; bin++ {
@@ -60,6 +67,15 @@ func TestRandomVec3InUnitSphere(t *testing.T) {
 	}
 }
 
+func TestRandomVec3OnUnitSphere(t *testing.T) {
+	randomGenerator := random.RandomGeneratedImpl{}
+
+	for i := 0; i < 10; i++ {
+		randomSphereVec := randomGenerator.Vec3OnUnitSphere()
+		assert.InDelta(t, 1, randomSphereVec.Len(), core.Tolerance)
+	}
+}
+
 func TestRandomVec3InUnitDisk(t *testing.T) {
 	randomGenerator := random.RandomGeneratedImpl{}
 
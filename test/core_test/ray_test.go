@@ -14,3 +14,11 @@ func TestRay_ShouldEvaluate(t *testing.T) {
 
 	assert.Equal(t, core.NewVec3(5, 8, 11), point)
 }
+
+func TestRay_ShouldBeTracedAtTimeZeroByDefault(t *testing.T) {
+	ray := core.NewRay(core.NewVec3(1, 2, 3), core.NewVec3(2, 3, 4))
+
+	assert.EqualValues(t, 0, ray.Time())
+	assert.EqualValues(t, 2, ray.AtTime(2).Time())
+	assert.Equal(t, ray.Direction(), ray.AtTime(2).Direction())
+}
@@ -0,0 +1,19 @@
+//go:build double
+
+package core_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReal_ShouldBeDoublePrecision(t *testing.T) {
+	far := core.NewVec3(1e8, 0, 0)
+
+	offset := far.Add(core.NewVec3(1, 0, 0)).Sub(far)
+
+	assert.Equal(t, 64, core.RealBitSize)
+	assert.Equal(t, core.NewVec3(1, 0, 0), offset)
+}
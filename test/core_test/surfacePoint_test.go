@@ -0,0 +1,38 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTangentFrame_ShouldOrthonormalizeTangent(t *testing.T) {
+	normal := core.NewVec3(0, 0, 1)
+
+	tangent, bitangent := core.TangentFrame(normal, core.NewVec3(2, 0, 2), core.NewVec3(0, 3, 0))
+
+	test.AssertInDeltaVec3(t, core.NewVec3(1, 0, 0), tangent, core.Tolerance)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 1, 0), bitangent, core.Tolerance)
+}
+
+func TestTangentFrame_ShouldKeepBitangentHandedness(t *testing.T) {
+	normal := core.NewVec3(0, 0, 1)
+
+	_, bitangent := core.TangentFrame(normal, core.NewVec3(1, 0, 0), core.NewVec3(0.2, -1, 0))
+
+	test.AssertInDeltaVec3(t, core.NewVec3(0, -1, 0), bitangent, core.Tolerance)
+}
+
+func TestTangentFrame_ShouldFallBackToAnyFrame_IfTangentParallelToNormal(t *testing.T) {
+	normal := core.NewVec3(0, 1, 0)
+
+	tangent, bitangent := core.TangentFrame(normal, core.NewVec3(0, 2, 0), core.NewVec3(0, 0, 1))
+
+	assert.InDelta(t, 1, tangent.Len(), core.Tolerance)
+	assert.InDelta(t, 1, bitangent.Len(), core.Tolerance)
+	assert.InDelta(t, 0, tangent.Dot(normal), core.Tolerance)
+	assert.InDelta(t, 0, bitangent.Dot(normal), core.Tolerance)
+	assert.InDelta(t, 0, tangent.Dot(bitangent), core.Tolerance)
+}
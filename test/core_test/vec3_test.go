@@ -4,7 +4,6 @@ import (
 	"testing"
 
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
-	"github.com/chewxy/math32"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,6 +15,12 @@ func TestVec3_ShouldReturnCoordinates(t *testing.T) {
 	assert.Equal(t, core.Real(3.), vec.Z())
 }
 
+func TestVec3_ShouldPermuteComponents(t *testing.T) {
+	vec := core.NewVec3(1., 2., 3.)
+
+	assert.Equal(t, core.NewVec3(3., 1., 2.), vec.Permute(2, 0, 1))
+}
+
 func TestVec3_ShouldAddVector(t *testing.T) {
 	vecA, vecB := core.NewVec3(1., 2., 3.), core.NewVec3(4., 5., 6.)
 
@@ -79,7 +84,7 @@ func TestVec3_ShouldComputeLength(t *testing.T) {
 
 	length := vec.Len()
 
-	assert.InDelta(t, math32.Sqrt(14), length, core.Tolerance)
+	assert.InDelta(t, core.Sqrt(14), length, core.Tolerance)
 }
 
 func TestVec3_ShouldComputeSquaredLength(t *testing.T) {
@@ -1,3 +1,6 @@
+//go:build !double
+
+// The reference images are rendered with float32 reals, double precision changes a few shades.
 package integration_test
 
 import (
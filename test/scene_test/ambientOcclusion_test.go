@@ -0,0 +1,72 @@
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmbientOcclusion_ShouldBeWhite_IfNothingAround(t *testing.T) {
+	scene := scene.New([]scene.Object{diffusiveFloor()}, flatBackground(),
+		scene.UseIntegrator(scene.NewAmbientOcclusion(16, 1, randomizer)))
+	ray := core.NewRay(core.NewVec3(0, 1, 0), core.NewVec3(0, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.White, rayColor)
+}
+
+func TestAmbientOcclusion_ShouldBeBlack_IfFullyOccluded(t *testing.T) {
+	dome := scene.Object{Hittable: geometries.NewSphere(core.NewVec3(0, 0, 0), 1), Material: materials.NewDiffusive(OBJECT_COLOR, randomizer)}
+	scene := scene.New([]scene.Object{diffusiveFloor(), dome}, flatBackground(),
+		scene.UseIntegrator(scene.NewAmbientOcclusion(16, 2, randomizer)))
+	ray := core.NewRay(core.NewVec3(0, 0.5, 0), core.NewVec3(0, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.Black, rayColor)
+}
+
+func TestAmbientOcclusion_ShouldIgnoreOccludersBeyondRadius(t *testing.T) {
+	dome := scene.Object{Hittable: geometries.NewSphere(core.NewVec3(0, 0, 0), 3), Material: materials.NewDiffusive(OBJECT_COLOR, randomizer)}
+	scene := scene.New([]scene.Object{diffusiveFloor(), dome}, flatBackground(),
+		scene.UseIntegrator(scene.NewAmbientOcclusion(16, 1, randomizer)))
+	ray := core.NewRay(core.NewVec3(0, 0.5, 0), core.NewVec3(0, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.White, rayColor)
+}
+
+func TestAmbientOcclusion_ShouldReturnBackground_IfNoHit(t *testing.T) {
+	scene := scene.New(noObjects, flatBackground(), scene.UseIntegrator(scene.NewAmbientOcclusion(16, 1, randomizer)))
+
+	rayColor := scene.TestRay(core.NewRay(anyPoint, anyDirection))
+
+	assert.Equal(t, BACKGROUND_COLOR, rayColor)
+}
+
+func TestAmbientOcclusion_ShouldValidateSettings(t *testing.T) {
+	assert.Panics(t, func() { scene.NewAmbientOcclusion(0, 1, randomizer) })
+	assert.Panics(t, func() { scene.NewAmbientOcclusion(16, 0, randomizer) })
+}
+
+func TestAmbientOcclusion_ShouldTestOccludersAtRayTime(t *testing.T) {
+	movingDome := scene.Object{
+		Hittable: geometries.NewMovingSphere(core.NewVec3(0, 0, 0), core.NewVec3(0, 0, 10), 0, 1, 1),
+		Material: materials.NewDiffusive(OBJECT_COLOR, randomizer)}
+	scene := scene.New([]scene.Object{diffusiveFloor(), movingDome}, flatBackground(),
+		scene.UseIntegrator(scene.NewAmbientOcclusion(16, 2, randomizer)))
+	origin, direction := core.NewVec3(0, 0.5, 0), core.NewVec3(0, -1, 0)
+
+	atStart := scene.TestRay(core.NewRayAtTime(origin, direction, 0))
+	atEnd := scene.TestRay(core.NewRayAtTime(origin, direction, 1))
+
+	assert.Equal(t, color.Black, atStart)
+	assert.Equal(t, color.White, atEnd)
+}
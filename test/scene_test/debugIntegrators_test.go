@@ -0,0 +1,37 @@
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugNormals_ShouldMapNormalToColor(t *testing.T) {
+	scene := scene.New([]scene.Object{unitSphere(OBJECT_COLOR)}, flatBackground(), scene.UseIntegrator(scene.NewDebugNormals()))
+	ray := core.NewRay(core.NewVec3(2, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.New(1, 0.5, 0.5), rayColor)
+}
+
+func TestDebugDepth_ShouldFadeWithDistance(t *testing.T) {
+	scene := scene.New([]scene.Object{unitSphere(OBJECT_COLOR)}, flatBackground(), scene.UseIntegrator(scene.NewDebugDepth(4)))
+	ray := core.NewRay(core.NewVec3(3, 0, 0), core.NewVec3(-2, 0, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.New(0.5, 0.5, 0.5), rayColor)
+}
+
+func TestDebugIntegrators_ShouldReturnBlack_IfNoHit(t *testing.T) {
+	ray := core.NewRay(anyPoint, anyDirection)
+
+	for _, integrator := range []scene.Integrator{scene.NewDebugNormals(), scene.NewDebugDepth(4)} {
+		scene := scene.New(noObjects, flatBackground(), scene.UseIntegrator(integrator))
+		assert.Equal(t, color.Black, scene.TestRay(ray))
+	}
+}
@@ -0,0 +1,85 @@
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/background"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectLighting_ShouldMatchPathTracer_ForSingleBounce(t *testing.T) {
+	objects := []scene.Object{diffusiveFloor(), sphereLight(core.NewVec3(0, 2, 0))}
+	fakeRandomizer := random.NewFakeRandomGenerator()
+	directLighting := scene.New(objects, background.NewFlatColor(color.Black),
+		scene.UseIntegrator(scene.NewDirectLighting(fakeRandomizer)))
+	pathTracing := scene.New(objects, background.NewFlatColor(color.Black),
+		scene.DirectLightSampling(fakeRandomizer))
+	ray := core.NewRay(core.NewVec3(0, 1, 0), core.NewVec3(0, -1, 0))
+
+	assert.Equal(t, pathTracing.TestRay(ray), directLighting.TestRay(ray))
+}
+
+func TestDirectLighting_ShouldSeeLightInMirror(t *testing.T) {
+	mirror := scene.Object{Hittable: diffusiveFloor().Hittable, Material: materials.NewReflective(OBJECT_COLOR, randomizer)}
+	objects := []scene.Object{mirror, sphereLight(core.NewVec3(1, 2, 0))}
+	scene := scene.New(objects, background.NewFlatColor(color.Black),
+		scene.UseIntegrator(scene.NewDirectLighting(randomizer)))
+	ray := core.NewRay(core.NewVec3(-1, 2, 0), core.NewVec3(1, -2, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, OBJECT_COLOR, rayColor)
+}
+
+func TestDirectLighting_ShouldIgnoreIndirectLight(t *testing.T) {
+	floor := diffusiveFloor()
+	floor.Material = materials.NewDiffusive(color.GrayMedium, random.NewFakeRandomGenerator())
+	// Reflects rays going up from the floor towards +X
+	tiltedMirror := geometries.NewQuad(
+		core.NewVec3(-1, 1, -1),
+		core.NewVec3(1, 3, -1),
+		core.NewVec3(1, 3, 1),
+		core.NewVec3(-1, 1, 1))
+	objects := []scene.Object{floor, {Hittable: tiltedMirror, Material: materials.NewReflective(color.White, randomizer)}}
+	pathTracing := scene.New(objects, flatBackground())
+	directLighting := scene.New(objects, flatBackground(),
+		scene.UseIntegrator(scene.NewDirectLighting(random.NewFakeRandomGenerator())))
+	ray := core.NewRay(core.NewVec3(2, 1, 0), core.NewVec3(-2, -1, 0))
+
+	pathTracingColor := pathTracing.TestRay(ray)
+	directLightingColor := directLighting.TestRay(ray)
+
+	assertColorsInDelta(t, color.GrayMedium.MulColor(BACKGROUND_COLOR), pathTracingColor, 1e-5)
+	assert.Equal(t, color.Black, directLightingColor)
+}
+
+func TestDirectLighting_ShouldColorRayBlack_IfMaxNumberOfReflectionsExceeded(t *testing.T) {
+	scene := scene.New(reflectiveXYAngle(), flatBackground(),
+		scene.UseIntegrator(scene.NewDirectLighting(randomizer, scene.DirectLightingMaxRayReflections(1))))
+	ray := core.NewRay(core.NewVec3(2, 1, 0), core.NewVec3(-1, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.Black, rayColor)
+}
+
+func TestDirectLighting_MaxRayReflectionsMustNotBeNegative(t *testing.T) {
+	assert.Panics(t, func() { scene.DirectLightingMaxRayReflections(-1) })
+}
+
+func TestDirectLighting_ShouldAbsorbLightInsideGlass(t *testing.T) {
+	absorption := color.New(0, 0, 0.5)
+	objects := []scene.Object{absorbingGlassSphere(core.NewVec3(0, 0, 0), 1, absorption)}
+	scene := scene.New(objects, flatBackground(), scene.UseIntegrator(scene.NewDirectLighting(randomizer)))
+	ray := core.NewRay(core.NewVec3(3, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assertColorsInDelta(t, BACKGROUND_COLOR.Mul(core.Exp(-1)), rayColor, 1e-5)
+}
@@ -0,0 +1,51 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBox_ShouldBeHitOnEntryFace(t *testing.T) {
+	box := geometries.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 2, 4))
+	ray := core.NewRay(core.NewVec3(0.5, 1, 6), core.NewVec3(0, 0, -1))
+
+	hit := box.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.EqualValues(t, 2, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(0, 0, 1), hit.Value().Normal)
+	test.AssertInDeltaVec2(t, core.NewVec2(0.5, 0.5), hit.Value().UV, 1e-5)
+}
+
+func TestBox_ShouldBeHitOnExitFace_IfRayStartsInside(t *testing.T) {
+	box := geometries.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 2, 4))
+	ray := core.NewRay(core.NewVec3(0.5, 1, 1), core.NewVec3(-1, 0, 0))
+
+	hit := box.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.EqualValues(t, 0.5, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(-1, 0, 0), hit.Value().Normal)
+	test.AssertInDeltaVec2(t, core.NewVec2(0.5, 0.25), hit.Value().UV, 1e-5)
+}
+
+func TestBox_ShouldMissRayPassingBy(t *testing.T) {
+	box := geometries.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 1, 1))
+	ray := core.NewRay(core.NewVec3(2, 0.5, -1), core.NewVec3(0, 0, 1))
+
+	hit := box.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.True(t, hit.Empty())
+}
+
+func TestBox_BoundingBoxShouldMatchBox(t *testing.T) {
+	box := geometries.NewBox(core.NewVec3(-1, 0, 1), core.NewVec3(1, 2, 4))
+
+	assert.Equal(t, core.NewBox(core.NewVec3(-1, 0, 1), core.NewVec3(1, 2, 4)), box.BoundingBox())
+}
+
+func TestBox_ShouldPanic_WhenMinExceedsMax(t *testing.T) {
+	assert.Panics(t, func() { geometries.NewBox(core.NewVec3(0, 1, 0), core.NewVec3(1, 0, 1)) })
+}
@@ -26,3 +26,79 @@ func TestBVHNode(t *testing.T) {
 	assert.Equal(t, core.NewVec3(2, 0, 0), hit.Value().Point)
 	assert.Equal(t, core.NewVec3(-1, 0, 0), hit.Value().Normal)
 }
+
+func TestBVH_ShouldFindSameHitsWithMedianAndSAHSplits(t *testing.T) {
+	for _, method := range []geometries.SplitMethod{geometries.SplitMedian, geometries.SplitSAH} {
+		bvh := geometries.BuildBVH(sphereRow(50), geometries.BVHSplitMethod(method))
+		ray := core.NewRay(core.NewVec3(-10, 0, 0), core.NewVec3(1, 0, 0))
+
+		hit := bvh.TestRay(ray, core.NewInterval(0, core.Inf()))
+
+		assert.EqualValues(t, 9.5, hit.Value().Param)
+		assert.EqualValues(t, 49.5, bvh.BoundingBox().Max().X())
+	}
+}
+
+func TestBVH_SAHShouldBeDeterministic(t *testing.T) {
+	first := geometries.BuildBVH(sphereClusters(), geometries.BVHSplitMethod(geometries.SplitSAH))
+	second := geometries.BuildBVH(sphereClusters(), geometries.BVHSplitMethod(geometries.SplitSAH))
+
+	assert.Equal(t, first.Stats(), second.Stats())
+}
+
+func TestBVH_SAHShouldHaveLowerCostThanMedian(t *testing.T) {
+	median := geometries.BuildBVH(sphereClusters(), geometries.BVHSplitMethod(geometries.SplitMedian))
+	sah := geometries.BuildBVH(sphereClusters(), geometries.BVHSplitMethod(geometries.SplitSAH))
+
+	assert.Less(t, sah.Stats().SAHCost, median.Stats().SAHCost)
+}
+
+func TestBVH_SAHShouldRespectMaxLeafSize(t *testing.T) {
+	// Coinciding spheres can't be separated by any split
+	spheres := []geometries.Hittable{}
+	for i := 0; i < 10; i++ {
+		spheres = append(spheres, geometries.NewSphere(core.NewVec3(0, 0, 0), 1))
+	}
+
+	bvh := geometries.BuildBVH(spheres, geometries.BVHMaxLeafSize(3))
+
+	assert.LessOrEqual(t, bvh.Stats().MaxLeafSize, 3)
+}
+
+func TestBVH_MedianStats(t *testing.T) {
+	bvh := geometries.BuildBVH(sphereRow(8), geometries.BVHSplitMethod(geometries.SplitMedian))
+
+	stats := bvh.Stats()
+
+	assert.Equal(t, 4, stats.Depth)
+	assert.Equal(t, 15, stats.NodeCount)
+	assert.Equal(t, 8, stats.LeafCount)
+	assert.Equal(t, 1, stats.MaxLeafSize)
+	assert.EqualValues(t, 1, stats.MeanLeafSize)
+}
+
+func TestBVH_ShouldPanic_IfInvalidSettings(t *testing.T) {
+	assert.Panics(t, func() { geometries.BVHMaxLeafSize(0) })
+	assert.Panics(t, func() { geometries.BVHSplitMethod(geometries.SplitMethod(42)) })
+}
+
+func sphereRow(count int) []geometries.Hittable {
+	spheres := []geometries.Hittable{}
+	for i := 0; i < count; i++ {
+		spheres = append(spheres, geometries.NewSphere(core.NewVec3(core.Real(i), 0, 0), 0.5))
+	}
+	return spheres
+}
+
+// Two dense clusters far apart, a bad case for the median split.
+func sphereClusters() []geometries.Hittable {
+	spheres := []geometries.Hittable{}
+	for i := 0; i < 64; i++ {
+		offset := core.Real(i%8) * 0.1
+		spheres = append(spheres, geometries.NewSphere(core.NewVec3(offset, core.Real(i/8)*0.1, 0), 0.05))
+	}
+	for i := 0; i < 8; i++ {
+		spheres = append(spheres, geometries.NewSphere(core.NewVec3(100+core.Real(i)*20, 0, 0), 0.05))
+	}
+	return spheres
+}
@@ -0,0 +1,59 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCone_ShouldBeHitOnSide(t *testing.T) {
+	cone := geometries.NewCone(core.NewVec3(0, 0, 0), core.NewVec3(0, 2, 0), 2)
+	ray := core.NewRay(core.NewVec3(-3, 1, 0), core.NewVec3(1, 0, 0))
+
+	hit := cone.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 2, hit.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 1, 0).Normalize(), hit.Value().Normal, 1e-5)
+	assert.InDelta(t, 0.5, hit.Value().UV.Y(), 1e-5)
+}
+
+func TestCone_ShouldBeHitOnBase(t *testing.T) {
+	cone := geometries.NewCone(core.NewVec3(0, 0, 0), core.NewVec3(0, 2, 0), 2)
+	ray := core.NewRay(core.NewVec3(1.5, -1, 0), core.NewVec3(0, 1, 0))
+
+	hit := cone.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 1, hit.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, -1, 0), hit.Value().Normal, 1e-5)
+}
+
+func TestCone_ShouldIgnoreMirroredConeAboveApex(t *testing.T) {
+	cone := geometries.NewCone(core.NewVec3(0, 0, 0), core.NewVec3(0, 2, 0), 2)
+	ray := core.NewRay(core.NewVec3(-3, 3, 0), core.NewVec3(1, 0, 0))
+
+	hit := cone.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.True(t, hit.Empty())
+}
+
+func TestCone_ShouldBeHitByRayParallelToSide(t *testing.T) {
+	cone := geometries.NewCone(core.NewVec3(0, 0, 0), core.NewVec3(0, 2, 0), 2)
+	// Parallel to the opposite side, so that the ray crosses the cone surface only once
+	ray := core.NewRay(core.NewVec3(-2, 3, 0), core.NewVec3(1, -1, 0))
+
+	hit := cone.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 1.5, hit.Value().Param, 1e-5)
+}
+
+func TestCone_BoundingBoxShouldContainApex(t *testing.T) {
+	cone := geometries.NewCone(core.NewVec3(0, 0, 0), core.NewVec3(3, 0, 0), 1)
+
+	box := cone.BoundingBox()
+
+	test.AssertInDeltaVec3(t, core.NewVec3(0, -1, -1), box.Min(), 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(3, 1, 1), box.Max(), 1e-5)
+}
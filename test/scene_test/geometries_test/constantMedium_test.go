@@ -0,0 +1,143 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantMedium_ShouldScatterAtEntry_IfFreeFlightIsZero(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+	medium := geometries.NewConstantMedium(boundary, 1, random.FakeRandomGenerator{RealValue: 0})
+	ray := core.NewRay(core.NewVec3(4, 0, 0), core.NewVec3(-1, 0, 0))
+
+	hit := medium.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.EqualValues(t, 2, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(1, 0, 0), hit.Value().Normal)
+}
+
+func TestConstantMedium_ShouldScatterAfterRayOrigin_IfRayStartsInside(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+	medium := geometries.NewConstantMedium(boundary, 1, random.FakeRandomGenerator{RealValue: 0})
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(-1, 0, 0))
+
+	hit := medium.TestRay(ray, core.NewInterval(0.5, 10))
+
+	assert.EqualValues(t, 0.5, hit.Value().Param)
+}
+
+func TestConstantMedium_ShouldScaleFreeFlightByRayLength(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, 0), 10)
+	medium := geometries.NewConstantMedium(boundary, 2, random.FakeRandomGenerator{RealValue: 1 - core.Exp(-1)})
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(0, 0, 2))
+
+	hit := medium.TestRay(ray, core.NewInterval(0, 10))
+
+	// Free flight of 1/2 along a direction of length 2
+	assert.InDelta(t, 0.25, hit.Value().Param, core.Tolerance)
+}
+
+func TestConstantMedium_ShouldPassThrough_IfFreeFlightTooLong(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+	medium := geometries.NewConstantMedium(boundary, 0.1, random.FakeRandomGenerator{RealValue: 0.99})
+	ray := core.NewRay(core.NewVec3(4, 0, 0), core.NewVec3(-1, 0, 0))
+
+	assert.True(t, medium.TestRay(ray, core.NewInterval(0, 10)).Empty())
+}
+
+func TestConstantMedium_ShouldTransmitExponentiallyWithThickness(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	medium := geometries.NewConstantMedium(boundary, 0.5, random.NewRandomGenerator())
+	ray := core.NewRay(core.NewVec3(4, 0, 0), core.NewVec3(-1, 0, 0))
+
+	const rays = 20000
+	passed := 0
+	for i := 0; i < rays; i++ {
+		if medium.TestRay(ray, core.NewInterval(0, 10)).Empty() {
+			passed++
+		}
+	}
+
+	assert.InDelta(t, core.Exp(-1), float32(passed)/rays, 0.02)
+}
+
+func TestConstantMedium_ShouldContinueFreeFlight_InNextPartOfBoundary(t *testing.T) {
+	boundary := geometries.NewLinearBVH([]geometries.Hittable{
+		geometries.NewSphere(core.NewVec3(-2, 0, 0), 1),
+		geometries.NewSphere(core.NewVec3(2, 0, 0), 1),
+	})
+	medium := geometries.NewConstantMedium(boundary, 1, random.FakeRandomGenerator{RealValue: 1 - core.Exp(-3)})
+	ray := core.NewRay(core.NewVec3(-10, 0, 0), core.NewVec3(1, 0, 0))
+
+	hit := medium.TestRay(ray, core.NewInterval(0, core.Inf()))
+
+	// Free flight of 3 through the first sphere of thickness 2 and 1 into the second
+	assert.InDelta(t, 12, hit.Value().Param, 1e-4)
+}
+
+func TestConstantMedium_DistantMediumShouldBeHit(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, -3000), 1)
+	medium := geometries.NewConstantMedium(boundary, 100, random.FakeRandomGenerator{RealValue: 0.5})
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(0, 0, -1))
+
+	hit := medium.TestRay(ray, core.NewInterval(0, core.Inf()))
+
+	assert.InDelta(t, 2999, hit.Value().Param, 1e-2)
+	assert.InDelta(t, 0, medium.Transmittance(ray, core.NewInterval(0, core.Inf())), 1e-6)
+}
+
+func TestConstantMedium_SmallMediumShouldBeHit_AlongLongRayDirection(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, 0), 0.01)
+	medium := geometries.NewConstantMedium(boundary, 1, random.FakeRandomGenerator{RealValue: 0})
+	ray := core.NewRay(core.NewVec3(0, 0, 1), core.NewVec3(0, 0, -1000))
+
+	hit := medium.TestRay(ray, core.NewInterval(0, core.Inf()))
+
+	assert.InDelta(t, 0.99e-3, hit.Value().Param, 1e-6)
+}
+
+func TestConstantMedium_ShouldMiss_IfBoundaryMissed(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	medium := geometries.NewConstantMedium(boundary, 1, random.FakeRandomGenerator{RealValue: 0})
+	ray := core.NewRay(core.NewVec3(4, 2, 0), core.NewVec3(-1, 0, 0))
+
+	assert.True(t, medium.TestRay(ray, core.NewInterval(0, 10)).Empty())
+}
+
+func TestConstantMedium_ShouldHaveBoundaryBoundingBox(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(1, 2, 3), 1)
+	medium := geometries.NewConstantMedium(boundary, 1, random.NewRandomGenerator())
+
+	assert.Equal(t, boundary.BoundingBox(), medium.BoundingBox())
+}
+
+func TestConstantMedium_DensityMustBePositive(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	assert.Panics(t, func() { geometries.NewConstantMedium(boundary, 0, random.NewRandomGenerator()) })
+}
+
+func TestConstantMedium_ShouldAttenuateByThicknessWithinParams(t *testing.T) {
+	boundary := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+	medium := geometries.NewConstantMedium(boundary, 0.5, random.NewRandomGenerator())
+	ray := core.NewRay(core.NewVec3(4, 0, 0), core.NewVec3(-2, 0, 0))
+
+	assert.InDelta(t, core.Exp(-2), medium.Transmittance(ray, core.NewInterval(0, 10)), core.Tolerance)
+	assert.InDelta(t, core.Exp(-0.5), medium.Transmittance(ray, core.NewInterval(0, 1.5)), core.Tolerance)
+	assert.EqualValues(t, 1, medium.Transmittance(ray, core.NewInterval(0, 0.5)))
+}
+
+func TestConstantMedium_ShouldAttenuateByAllPartsOfBoundary(t *testing.T) {
+	boundary := geometries.NewLinearBVH([]geometries.Hittable{
+		geometries.NewSphere(core.NewVec3(-2, 0, 0), 1),
+		geometries.NewSphere(core.NewVec3(2, 0, 0), 1),
+	})
+	medium := geometries.NewConstantMedium(boundary, 0.5, random.NewRandomGenerator())
+	ray := core.NewRay(core.NewVec3(-10, 0, 0), core.NewVec3(1, 0, 0))
+
+	assert.InDelta(t, core.Exp(-2), medium.Transmittance(ray, core.NewInterval(0, 20)), core.Tolerance)
+	assert.InDelta(t, core.Exp(-1.5), medium.Transmittance(ray, core.NewInterval(0, 12)), core.Tolerance)
+}
@@ -0,0 +1,103 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func lens() geometries.CSG {
+	return geometries.NewIntersection(
+		geometries.NewSphere(core.NewVec3(-1, 0, 0), 2),
+		geometries.NewSphere(core.NewVec3(1, 0, 0), 2))
+}
+
+func TestCSG_UnionShouldMergeOverlappingSpans(t *testing.T) {
+	union := geometries.NewUnion(
+		geometries.NewSphere(core.NewVec3(-1, 0, 0), 2),
+		geometries.NewSphere(core.NewVec3(1, 0, 0), 2))
+	ray := core.NewRay(core.NewVec3(-5, 0, 0), core.NewVec3(1, 0, 0))
+
+	spans := union.Spans(ray)
+
+	assert.Len(t, spans, 1)
+	assert.EqualValues(t, 2, spans[0].Entry.Param)
+	assert.EqualValues(t, 8, spans[0].Exit.Param)
+}
+
+func TestCSG_IntersectionShouldBeHitOnLensSurface(t *testing.T) {
+	ray := core.NewRay(core.NewVec3(-5, 0, 0), core.NewVec3(1, 0, 0))
+
+	hit := lens().TestRay(ray, core.NewInterval(0, 10))
+
+	assert.EqualValues(t, 4, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(-1, 0, 0), hit.Value().Normal)
+}
+
+func TestCSG_IntersectionShouldHaveOverlappingBoundingBox(t *testing.T) {
+	box := lens().BoundingBox()
+
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, -2, -2), box.Min(), 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(1, 2, 2), box.Max(), 1e-5)
+}
+
+func TestCSG_DifferenceShouldFlipNormalsOfSubtractedSurface(t *testing.T) {
+	hollowed := geometries.NewDifference(
+		geometries.NewSphere(core.NewVec3(0, 0, 0), 2),
+		geometries.NewSphere(core.NewVec3(0, 0, 0), 1))
+	ray := core.NewRay(core.NewVec3(-5, 0, 0), core.NewVec3(1, 0, 0))
+
+	spans := hollowed.Spans(ray)
+
+	assert.Len(t, spans, 2)
+	assert.EqualValues(t, 4, spans[0].Exit.Param)
+	assert.Equal(t, core.NewVec3(1, 0, 0), spans[0].Exit.Normal)
+	assert.EqualValues(t, 6, spans[1].Entry.Param)
+	assert.Equal(t, core.NewVec3(-1, 0, 0), spans[1].Entry.Normal)
+}
+
+func TestCSG_DistantDifferenceShouldBeHit(t *testing.T) {
+	hollowed := geometries.NewDifference(
+		geometries.NewSphere(core.NewVec3(0, 0, -3000), 2),
+		geometries.NewSphere(core.NewVec3(0, 0, -3000), 1))
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(0, 0, -1))
+
+	hit := hollowed.TestRay(ray, core.NewInterval(0, core.Inf()))
+
+	assert.InDelta(t, 2998, hit.Value().Param, 1e-3)
+	assert.Len(t, hollowed.Spans(ray), 2)
+}
+
+func TestCSG_DrilledBoxShouldLetRaysThroughHole(t *testing.T) {
+	drilled := geometries.NewDifference(
+		geometries.NewBox(core.NewVec3(-1, -1, -1), core.NewVec3(1, 1, 1)),
+		geometries.NewCylinder(core.NewVec3(0, -2, 0), core.NewVec3(0, 2, 0), 0.5))
+
+	throughHole := drilled.TestRay(core.NewRay(core.NewVec3(0, 5, 0), core.NewVec3(0, -1, 0)), core.NewInterval(0, 10))
+	intoWall := drilled.TestRay(core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0)), core.NewInterval(0, 10))
+
+	assert.True(t, throughHole.Empty())
+	assert.InDelta(t, 0.5, intoWall.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 0, 0), intoWall.Value().Normal, 1e-5)
+}
+
+func TestCSG_ShouldBeHitFromInside(t *testing.T) {
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0))
+
+	hit := lens().TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, core.Sqrt(3), hit.Value().Param, 1e-5)
+}
+
+func TestCSG_ShouldCombineNestedCSGs(t *testing.T) {
+	lensWithHole := geometries.NewDifference(lens(),
+		geometries.NewCylinder(core.NewVec3(-3, 0, 0), core.NewVec3(3, 0, 0), 0.5))
+	ray := core.NewRay(core.NewVec3(-5, 0, 0), core.NewVec3(1, 0, 0))
+	offAxis := core.NewRay(core.NewVec3(-5, 1, 0), core.NewVec3(1, 0, 0))
+
+	assert.True(t, lensWithHole.TestRay(ray, core.NewInterval(0, 10)).Empty())
+	assert.True(t, lensWithHole.TestRay(offAxis, core.NewInterval(0, 10)).Present())
+}
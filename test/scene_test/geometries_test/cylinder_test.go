@@ -0,0 +1,61 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCylinder_ShouldBeHitOnSide(t *testing.T) {
+	cylinder := geometries.NewCylinder(core.NewVec3(0, 0, 0), core.NewVec3(0, 2, 0), 1)
+	ray := core.NewRay(core.NewVec3(-3, 1.5, 0), core.NewVec3(1, 0, 0))
+
+	hit := cylinder.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 2, hit.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 0, 0), hit.Value().Normal, 1e-5)
+	assert.InDelta(t, 0.75, hit.Value().UV.Y(), 1e-5)
+}
+
+func TestCylinder_ShouldBeHitOnCaps(t *testing.T) {
+	cylinder := geometries.NewCylinder(core.NewVec3(0, 0, 0), core.NewVec3(0, 2, 0), 1)
+
+	top := cylinder.TestRay(core.NewRay(core.NewVec3(0.5, 5, 0), core.NewVec3(0, -1, 0)), core.NewInterval(0, 10))
+	bottom := cylinder.TestRay(core.NewRay(core.NewVec3(0.5, -5, 0), core.NewVec3(0, 1, 0)), core.NewInterval(0, 10))
+
+	assert.InDelta(t, 3, top.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 1, 0), top.Value().Normal, 1e-5)
+	assert.InDelta(t, 5, bottom.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, -1, 0), bottom.Value().Normal, 1e-5)
+}
+
+func TestCylinder_ShouldMissRayAboveTop(t *testing.T) {
+	cylinder := geometries.NewCylinder(core.NewVec3(0, 0, 0), core.NewVec3(0, 2, 0), 1)
+	ray := core.NewRay(core.NewVec3(-3, 2.5, 0), core.NewVec3(1, 0, 0))
+
+	hit := cylinder.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.True(t, hit.Empty())
+}
+
+func TestCylinder_ShouldBeHitFromInside(t *testing.T) {
+	cylinder := geometries.NewCylinder(core.NewVec3(0, 0, 0), core.NewVec3(0, 0, 4), 1)
+	ray := core.NewRay(core.NewVec3(0, 0, 1), core.NewVec3(0, 1, 0))
+
+	hit := cylinder.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 1, hit.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 1, 0), hit.Value().Normal, 1e-5)
+}
+
+func TestCylinder_ShouldHaveTightBoundingBox(t *testing.T) {
+	cylinder := geometries.NewCylinder(core.NewVec3(0, 0, 0), core.NewVec3(0, 2, 0), 1)
+
+	box := cylinder.BoundingBox()
+
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 0, -1), box.Min(), 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(1, 2, 1), box.Max(), 1e-5)
+}
@@ -0,0 +1,57 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/stretchr/testify/assert"
+)
+
+var UNIT_BOX = core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 1, 1))
+
+// Voxel centers at 0.25 and 0.75 along X, the densities don't change along Y and Z
+func gradientGrid() *geometries.DensityGrid {
+	return geometries.NewDensityGrid([3]int{2, 1, 1}, UNIT_BOX, []core.Real{1, 3})
+}
+
+func TestDensityGrid_ShouldReturnVoxelDensities_AtVoxelCenters(t *testing.T) {
+	grid := gradientGrid()
+
+	assert.InDelta(t, 1, grid.DensityAt(core.NewVec3(0.25, 0.5, 0.5)), core.Tolerance)
+	assert.InDelta(t, 3, grid.DensityAt(core.NewVec3(0.75, 0.5, 0.5)), core.Tolerance)
+}
+
+func TestDensityGrid_ShouldInterpolateTrilinearly(t *testing.T) {
+	grid := geometries.NewDensityGrid([3]int{2, 2, 2}, UNIT_BOX, []core.Real{0, 1, 2, 3, 4, 5, 6, 7})
+
+	assert.InDelta(t, 3.5, grid.DensityAt(core.NewVec3(0.5, 0.5, 0.5)), core.Tolerance)
+	assert.InDelta(t, 0.5, grid.DensityAt(core.NewVec3(0.5, 0.25, 0.25)), core.Tolerance)
+	assert.InDelta(t, 5, grid.DensityAt(core.NewVec3(0.25, 0.5, 0.75)), core.Tolerance)
+}
+
+func TestDensityGrid_ShouldClampBetweenVoxelCentersAndBounds(t *testing.T) {
+	grid := gradientGrid()
+
+	assert.InDelta(t, 1, grid.DensityAt(core.NewVec3(0.1, 0.5, 0.5)), core.Tolerance)
+	assert.InDelta(t, 3, grid.DensityAt(core.NewVec3(1, 0, 1)), core.Tolerance)
+}
+
+func TestDensityGrid_ShouldBeEmpty_OutsideBounds(t *testing.T) {
+	grid := gradientGrid()
+
+	assert.EqualValues(t, 0, grid.DensityAt(core.NewVec3(1.1, 0.5, 0.5)))
+	assert.EqualValues(t, 0, grid.DensityAt(core.NewVec3(0.5, -0.1, 0.5)))
+}
+
+func TestDensityGrid_ShouldTrackMaxDensity(t *testing.T) {
+	assert.EqualValues(t, 3, gradientGrid().MaxDensity())
+}
+
+func TestDensityGrid_ShouldValidateInput(t *testing.T) {
+	assert.Panics(t, func() { geometries.NewDensityGrid([3]int{2, 1, 1}, UNIT_BOX, []core.Real{1}) })
+	assert.Panics(t, func() { geometries.NewDensityGrid([3]int{0, 1, 1}, UNIT_BOX, []core.Real{}) })
+	assert.Panics(t, func() { geometries.NewDensityGrid([3]int{1, 1, 1}, UNIT_BOX, []core.Real{-1}) })
+	flatBox := core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 1))
+	assert.Panics(t, func() { geometries.NewDensityGrid([3]int{1, 1, 1}, flatBox, []core.Real{1}) })
+}
@@ -0,0 +1,63 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisk_ShouldBeHitInsideRadius(t *testing.T) {
+	disk := geometries.NewDisk(core.NewVec3(0, 0, 0), core.NewVec3(0, 0, 1), 2)
+	down := core.NewVec3(0, 0, -1)
+
+	inside := disk.TestRay(core.NewRay(core.NewVec3(1, 1, 3), down), core.NewInterval(0, 10))
+	outside := disk.TestRay(core.NewRay(core.NewVec3(2, 1, 3), down), core.NewInterval(0, 10))
+
+	assert.EqualValues(t, 3, inside.Value().Param)
+	assert.Equal(t, core.NewVec3(0, 0, 1), inside.Value().Normal)
+	assert.True(t, outside.Empty())
+}
+
+func TestDisk_UVShouldSpanUnitSquare(t *testing.T) {
+	disk := geometries.NewDisk(core.NewVec3(0, 0, 0), core.NewVec3(0, 0, 1), 2)
+	down := core.NewVec3(0, 0, -1)
+
+	center := disk.TestRay(core.NewRay(core.NewVec3(0, 0, 1), down), core.NewInterval(0, 10)).Value()
+	edge := disk.TestRay(core.NewRay(core.NewVec3(0, 1.999, 1), down), core.NewInterval(0, 10)).Value()
+
+	test.AssertInDeltaVec2(t, core.NewVec2(0.5, 0.5), center.UV, 1e-5)
+	offset := edge.UV.Sub(center.UV)
+	assert.InDelta(t, 0.5, core.Hypot(offset.X(), offset.Y()), 1e-3)
+}
+
+func TestDisk_ShouldHaveTightBoundingBox(t *testing.T) {
+	disk := geometries.NewDisk(core.NewVec3(1, 2, 3), core.NewVec3(1, 0, 1), 2)
+
+	box := disk.BoundingBox()
+
+	test.AssertInDeltaVec3(t, core.NewVec3(1-core.Sqrt2, 0, 3-core.Sqrt2), box.Min(), 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(1+core.Sqrt2, 4, 3+core.Sqrt2), box.Max(), 1e-5)
+}
+
+func TestDisk_SamplesShouldLieOnDisk(t *testing.T) {
+	disk := geometries.NewDisk(core.NewVec3(1, 2, 3), core.NewVec3(0, 1, 0), 2)
+	origin := core.NewVec3(1, 5, 3)
+	randomizer := random.NewRandomGenerator()
+
+	for i := 0; i < 20; i++ {
+		sample := disk.Sample(origin, randomizer)
+		ray := core.NewRay(origin, sample.Point.Sub(origin))
+		hit := disk.TestRay(ray, core.NewInterval(0, 2))
+
+		assert.InDelta(t, 2, sample.Point.Y(), 1e-5)
+		assert.InDelta(t, sample.PDF, disk.PDF(origin, hit.Value()), 1e-3*float64(sample.PDF))
+	}
+}
+
+func TestDisk_ShouldPanic_WhenRadiusIsNotPositive(t *testing.T) {
+	assert.Panics(t, func() { geometries.NewDisk(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0), 0) })
+}
@@ -0,0 +1,75 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistanceFunction_BoxShouldBeNegativeInside(t *testing.T) {
+	box := geometries.BoxDistance(core.NewVec3(0, 0, 0), core.NewVec3(1, 2, 3))
+
+	assert.InDelta(t, -1, box(core.NewVec3(0, 0, 0)), 1e-5)
+	assert.InDelta(t, 1, box(core.NewVec3(0, 3, 0)), 1e-5)
+	assert.InDelta(t, core.Sqrt(2), box(core.NewVec3(2, 3, 0)), 1e-5)
+}
+
+func TestDistanceFunction_TorusShouldBeZeroOnTube(t *testing.T) {
+	torus := geometries.TorusDistance(core.NewVec3(0, 0, 0), 2, 0.5)
+
+	assert.InDelta(t, 0, torus(core.NewVec3(2.5, 0, 0)), 1e-5)
+	assert.InDelta(t, 0, torus(core.NewVec3(0, 0.5, -2)), 1e-5)
+	assert.InDelta(t, 1.5, torus(core.NewVec3(0, 0, 0)), 1e-5)
+}
+
+func TestDistanceFunction_SmoothUnionShouldFillGapBetweenShapes(t *testing.T) {
+	a := geometries.SphereDistance(core.NewVec3(-1.1, 0, 0), 1)
+	b := geometries.SphereDistance(core.NewVec3(1.1, 0, 0), 1)
+	between := core.NewVec3(0, 0, 0)
+
+	union := a.SmoothUnion(b, 0)
+	smoothUnion := a.SmoothUnion(b, 0.5)
+
+	assert.InDelta(t, 0.1, union(between), 1e-5)
+	assert.Less(t, smoothUnion(between), core.Real(0))
+	assert.InDelta(t, a(core.NewVec3(-3, 0, 0)), smoothUnion(core.NewVec3(-3, 0, 0)), 1e-5)
+}
+
+func TestDistanceFunction_RoundShouldInflateShape(t *testing.T) {
+	box := geometries.BoxDistance(core.NewVec3(0, 0, 0), core.NewVec3(1, 1, 1)).Round(0.5)
+
+	assert.InDelta(t, 0, box(core.NewVec3(1.5, 0, 0)), 1e-5)
+	assert.InDelta(t, 0, box(core.NewVec3(1, 1, 1).Add(core.NewVec3(1, 1, 1).Normalize().Mul(0.5))), 1e-5)
+}
+
+func TestDistanceFunction_RepeatShouldCopyShapeAlongAxes(t *testing.T) {
+	spheres := geometries.SphereDistance(core.NewVec3(0, 0, 0), 1).Repeat(core.NewVec3(4, 0, 4))
+
+	assert.InDelta(t, -1, spheres(core.NewVec3(8, 0, -12)), 1e-5)
+	assert.InDelta(t, 1, spheres(core.NewVec3(2, 0, 0)), 1e-5)
+	assert.InDelta(t, 7, spheres(core.NewVec3(0, 8, 0)), 1e-5)
+}
+
+func TestDistanceFunction_TwistShouldRotateSlicesByHeight(t *testing.T) {
+	bar := geometries.BoxDistance(core.NewVec3(0, 0, 0), core.NewVec3(2, 5, 0.5))
+	twisted := bar.Twist(18)
+
+	// At height 5 the bar is turned by 90 degrees
+	assert.InDelta(t, bar(core.NewVec3(1.5, 0, 0)), twisted(core.NewVec3(1.5, 0, 0)), 1e-5)
+	assert.Less(t, twisted(core.NewVec3(0, 4.99, 1.5)), core.Real(0))
+	assert.Greater(t, twisted(core.NewVec3(1.5, 4.99, 0)), core.Real(0))
+}
+
+func TestDistanceFunction_TwistedSDFShouldBeHit_WithSmallerSteps(t *testing.T) {
+	bar := geometries.BoxDistance(core.NewVec3(0, 0, 0), core.NewVec3(2, 5, 0.5)).Twist(18)
+	sdf := geometries.NewSDF(bar, core.NewBox(core.NewVec3(-3, -5, -3), core.NewVec3(3, 5, 3)), geometries.SDFStepScale(0.5))
+	ray := core.NewRay(core.NewVec3(0, 4.99, 5), core.NewVec3(0, 0, -1))
+
+	hit := sdf.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 3, hit.Value().Param, 1e-2)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 0, 1), hit.Value().Normal, 0.1)
+}
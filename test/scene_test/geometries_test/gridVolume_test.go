@@ -0,0 +1,68 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/stretchr/testify/assert"
+)
+
+// Crosses the unit box along X
+var RAY_THROUGH_UNIT_BOX = core.NewRay(core.NewVec3(-1, 0.5, 0.5), core.NewVec3(1, 0, 0))
+
+func TestGridVolume_DeltaTrackingShouldMatchTransmittance(t *testing.T) {
+	volume := geometries.NewGridVolume(gradientGrid(), 0.5, random.NewRandomGenerator())
+
+	const rays = 20000
+	passed := 0
+	for i := 0; i < rays; i++ {
+		if volume.TestRay(RAY_THROUGH_UNIT_BOX, core.NewInterval(0, 10)).Empty() {
+			passed++
+		}
+	}
+
+	// The density grows from 1 to 3 between the voxel centers and stays constant beyond them,
+	// its integral over the box is 2, scaled by 0.5
+	assert.InDelta(t, core.Exp(-1), float32(passed)/rays, 0.02)
+}
+
+func TestGridVolume_RatioTrackingShouldEstimateTransmittance(t *testing.T) {
+	volume := geometries.NewGridVolume(gradientGrid(), 0.5, random.NewRandomGenerator())
+
+	const rays = 20000
+	transmittance := core.Real(0)
+	for i := 0; i < rays; i++ {
+		transmittance += volume.Transmittance(RAY_THROUGH_UNIT_BOX, core.NewInterval(0, 10)) / rays
+	}
+
+	assert.InDelta(t, core.Exp(-1), transmittance, 0.01)
+}
+
+func TestGridVolume_ShouldOnlyTrackWithinParams(t *testing.T) {
+	volume := geometries.NewGridVolume(gradientGrid(), 100, random.NewRandomGenerator())
+
+	hit := volume.TestRay(RAY_THROUGH_UNIT_BOX, core.NewInterval(1.5, 10))
+
+	assert.GreaterOrEqual(t, hit.Value().Param, core.Real(1.5))
+	assert.EqualValues(t, 1, volume.Transmittance(RAY_THROUGH_UNIT_BOX, core.NewInterval(0, 1)))
+}
+
+func TestGridVolume_ShouldBeTransparent_IfGridEmpty(t *testing.T) {
+	grid := geometries.NewDensityGrid([3]int{1, 1, 1}, UNIT_BOX, []core.Real{0})
+	volume := geometries.NewGridVolume(grid, 1, random.NewRandomGenerator())
+
+	assert.True(t, volume.TestRay(RAY_THROUGH_UNIT_BOX, core.NewInterval(0, 10)).Empty())
+	assert.EqualValues(t, 1, volume.Transmittance(RAY_THROUGH_UNIT_BOX, core.NewInterval(0, 10)))
+}
+
+func TestGridVolume_ShouldHaveGridBoundingBox(t *testing.T) {
+	volume := geometries.NewGridVolume(gradientGrid(), 1, random.NewRandomGenerator())
+
+	assert.Equal(t, UNIT_BOX, volume.BoundingBox())
+}
+
+func TestGridVolume_DensityScaleMustBePositive(t *testing.T) {
+	assert.Panics(t, func() { geometries.NewGridVolume(gradientGrid(), 0, random.NewRandomGenerator()) })
+}
@@ -0,0 +1,108 @@
+package geometries_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var TERRAIN_BOUNDS = core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(4, 4, 4))
+
+// A ramp along X with the surface y = x
+func rampHeightfield() *geometries.Heightfield {
+	heights := []core.Real{}
+	for j := 0; j < 5; j++ {
+		for i := 0; i < 5; i++ {
+			heights = append(heights, core.Real(i)/4)
+		}
+	}
+	return geometries.NewHeightfield([2]int{5, 5}, TERRAIN_BOUNDS, heights)
+}
+
+func TestHeightfield_ShouldBeHitOnFlatTerrain(t *testing.T) {
+	heights := []core.Real{0.5, 0.5, 0.5, 0.5}
+	field := geometries.NewHeightfield([2]int{2, 2}, TERRAIN_BOUNDS, heights)
+	ray := core.NewRay(core.NewVec3(1, 5, 3), core.NewVec3(0, -1, 0))
+
+	hit := field.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 3, hit.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 1, 0), hit.Value().Normal, 1e-5)
+	test.AssertInDeltaVec2(t, core.NewVec2(0.25, 0.75), hit.Value().UV, 1e-5)
+}
+
+func TestHeightfield_ShouldBeHitOnSlope(t *testing.T) {
+	ray := core.NewRay(core.NewVec3(-1, 2, 1.3), core.NewVec3(1, 0, 0))
+
+	hit := rampHeightfield().TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 3, hit.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 1, 0).Normalize(), hit.Value().Normal, 1e-5)
+}
+
+func TestHeightfield_ShouldMissRayAboveTerrain(t *testing.T) {
+	ray := core.NewRay(core.NewVec3(-1, 4.5, 1), core.NewVec3(1, 0, 1))
+
+	hit := rampHeightfield().TestRay(ray, core.NewInterval(0, 10))
+
+	assert.True(t, hit.Empty())
+}
+
+func TestHeightfield_ShouldMatchTriangleMesh(t *testing.T) {
+	const size = 9
+	random := rand.New(rand.NewSource(1))
+	heights := make([]core.Real, size*size)
+	for i := range heights {
+		heights[i] = core.Real(random.Float32())
+	}
+	field := geometries.NewHeightfield([2]int{size, size}, TERRAIN_BOUNDS, heights)
+	mesh := heightfieldMesh(heights, size)
+
+	for i := 0; i < 200; i++ {
+		origin := core.NewVec3(core.Real(random.Float32())*8-2, 5, core.Real(random.Float32())*8-2)
+		target := core.NewVec3(core.Real(random.Float32())*4, core.Real(random.Float32())*4, core.Real(random.Float32())*4)
+		ray := core.NewRay(origin, target.Sub(origin))
+
+		expected := mesh.TestRay(ray, core.NewInterval(0, 10))
+		hit := field.TestRay(ray, core.NewInterval(0, 10))
+
+		assert.Equal(t, expected.Present(), hit.Present())
+		if expected.Present() && hit.Present() {
+			assert.InDelta(t, expected.Value().Param, hit.Value().Param, 1e-4)
+		}
+	}
+}
+
+func heightfieldMesh(heights []core.Real, size int) geometries.Mesh {
+	step := core.Real(4) / core.Real(size-1)
+	vertex := func(i, j int) core.Vec3 {
+		return core.NewVec3(core.Real(i)*step, 4*heights[j*size+i], core.Real(j)*step)
+	}
+	triangles := []geometries.Triangle{}
+	for j := 0; j < size-1; j++ {
+		for i := 0; i < size-1; i++ {
+			triangles = append(triangles,
+				geometries.NewTriangle(vertex(i, j), vertex(i, j+1), vertex(i+1, j)),
+				geometries.NewTriangle(vertex(i+1, j+1), vertex(i+1, j), vertex(i, j+1)))
+		}
+	}
+	return geometries.NewMesh(triangles)
+}
+
+func TestHeightfield_BoundingBoxShouldSpanHeightRange(t *testing.T) {
+	field := geometries.NewHeightfield([2]int{2, 2}, TERRAIN_BOUNDS, []core.Real{0.25, 0.5, 0.5, 0.75})
+
+	box := field.BoundingBox()
+
+	assert.Equal(t, core.NewVec3(0, 1, 0), box.Min())
+	assert.Equal(t, core.NewVec3(4, 3, 4), box.Max())
+}
+
+func TestHeightfield_ShouldPanic_IfHeightCountDoesntMatchResolution(t *testing.T) {
+	assert.Panics(t, func() { geometries.NewHeightfield([2]int{2, 2}, TERRAIN_BOUNDS, []core.Real{0, 0, 0}) })
+	assert.Panics(t, func() { geometries.NewHeightfield([2]int{1, 3}, TERRAIN_BOUNDS, []core.Real{0, 0, 0}) })
+}
@@ -0,0 +1,169 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// A unit cube around the origin as a triangle soup, every face with its own three vertices
+func cubeSoup() *geometries.IndexedMesh {
+	corner := func(i int) core.Vec3 {
+		return core.NewVec3(core.Real(i&1)-0.5, core.Real(i>>1&1)-0.5, core.Real(i>>2&1)-0.5)
+	}
+	quads := [][4]int{{0, 2, 3, 1}, {4, 5, 7, 6}, {0, 1, 5, 4}, {2, 6, 7, 3}, {0, 4, 6, 2}, {1, 3, 7, 5}}
+
+	positions := []core.Vec3{}
+	faces := [][3]int{}
+	for _, quad := range quads {
+		for _, triangle := range [][3]int{{quad[0], quad[1], quad[2]}, {quad[2], quad[3], quad[0]}} {
+			index := len(positions)
+			positions = append(positions, corner(triangle[0]), corner(triangle[1]), corner(triangle[2]))
+			faces = append(faces, [3]int{index, index + 1, index + 2})
+		}
+	}
+	return geometries.NewIndexedMesh(positions, faces)
+}
+
+func TestIndexedMesh_WeldShouldMergeSharedVertices(t *testing.T) {
+	cube := cubeSoup().Weld(1e-5)
+
+	assert.Len(t, cube.Positions(), 8)
+	assert.Len(t, cube.Faces(), 12)
+}
+
+func TestIndexedMesh_WeldShouldKeepVerticesWithDifferentUVs(t *testing.T) {
+	positions := []core.Vec3{core.NewVec3(0, 0, 0), core.NewVec3(1e-6, 0, 0), core.NewVec3(0, 0, 0)}
+	uvs := []core.Vec2{core.NewVec2(0, 0), core.NewVec2(0, 0), core.NewVec2(1, 0)}
+	mesh := geometries.NewIndexedMesh(positions, nil).WithUVs(uvs).Weld(1e-5)
+
+	assert.Len(t, mesh.Positions(), 2)
+	assert.Len(t, mesh.UVs(), 2)
+}
+
+func TestIndexedMesh_WeldShouldRemoveCollapsedFaces(t *testing.T) {
+	positions := []core.Vec3{core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0), core.NewVec3(1, 1e-6, 0)}
+	mesh := geometries.NewIndexedMesh(positions, [][3]int{{0, 1, 2}}).Weld(1e-5)
+
+	assert.Len(t, mesh.Positions(), 2)
+	assert.Empty(t, mesh.Faces())
+}
+
+func TestIndexedMesh_SmoothNormalsShouldSplitVerticesOnCreases(t *testing.T) {
+	cube := cubeSoup().Weld(1e-5).SmoothNormals(30, geometries.AngleWeighting)
+
+	assert.Len(t, cube.Positions(), 24)
+	for i, normal := range cube.Normals() {
+		axisAligned := core.Abs(normal.X()) + core.Abs(normal.Y()) + core.Abs(normal.Z())
+		assert.InDelta(t, 1, axisAligned, 1e-5, "normal %d: %v", i, normal)
+	}
+}
+
+func TestIndexedMesh_AngleWeightedNormalsShouldPointAlongCubeDiagonals(t *testing.T) {
+	cube := cubeSoup().Weld(1e-5).SmoothNormals(180, geometries.AngleWeighting)
+
+	assert.Len(t, cube.Positions(), 8)
+	for i, position := range cube.Positions() {
+		test.AssertInDeltaVec3(t, position.Normalize(), cube.Normals()[i], 1e-5)
+	}
+}
+
+func TestIndexedMesh_AreaWeightedNormalsShouldFavorLargerFaces(t *testing.T) {
+	// A tall and a short face meeting at a right angle along the X axis
+	positions := []core.Vec3{
+		core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0),
+		core.NewVec3(0, 0, 3), core.NewVec3(0, 1, 0)}
+	faces := [][3]int{{0, 1, 2}, {0, 3, 1}}
+	mesh := geometries.NewIndexedMesh(positions, faces).SmoothNormals(180, geometries.AreaWeighting)
+
+	normal := mesh.Normals()[0]
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 1.5, 0.5).Normalize(), normal.Mul(-1), 1e-5)
+}
+
+func TestIndexedMesh_SubdivideShouldQuadrupleFaces(t *testing.T) {
+	cube := cubeSoup().Weld(1e-5)
+
+	subdivided := cube.Subdivide(2)
+
+	// Every step adds a vertex per edge, a closed cube has 18 edges, the subdivided one 72
+	assert.Len(t, subdivided.Positions(), 8+18+72)
+	assert.Len(t, subdivided.Faces(), 12*16)
+}
+
+func TestIndexedMesh_SubdivisionShouldShrinkClosedMeshTowardsSmoothSurface(t *testing.T) {
+	cube := cubeSoup().Weld(1e-5).Subdivide(3)
+
+	for _, position := range cube.Positions() {
+		assert.Less(t, position.Len(), core.Real(core.Sqrt(3)/2))
+		assert.Greater(t, position.Len(), core.Real(0.25))
+	}
+}
+
+func TestIndexedMesh_SubdivisionShouldKeepFlatBoundaryInPlane(t *testing.T) {
+	positions := []core.Vec3{core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0), core.NewVec3(1, 1, 0), core.NewVec3(0, 1, 0)}
+	uvs := []core.Vec2{core.NewVec2(0, 0), core.NewVec2(1, 0), core.NewVec2(1, 1), core.NewVec2(0, 1)}
+	quad := geometries.NewIndexedMesh(positions, [][3]int{{0, 1, 2}, {2, 3, 0}}).WithUVs(uvs).Subdivide(1)
+
+	assert.Len(t, quad.Positions(), 9)
+	assert.Len(t, quad.UVs(), 9)
+	for _, position := range quad.Positions() {
+		assert.Equal(t, core.Real(0), position.Z())
+	}
+	// The midpoint of the boundary edge from 0 to 1
+	test.AssertInDeltaVec3(t, core.NewVec3(0.5, 0, 0), quad.Positions()[4], 1e-6)
+	test.AssertInDeltaVec2(t, core.NewVec2(0.5, 0), quad.UVs()[4], 1e-6)
+}
+
+func TestIndexedMesh_BuildShouldMatchTriangleMesh(t *testing.T) {
+	indexed := cubeSoup().Weld(1e-5).SmoothNormals(30, geometries.AreaWeighting).Build()
+	reference := cubeMesh()
+	rays := []core.Ray{
+		core.NewRay(core.NewVec3(0.1, 0.2, 3), core.NewVec3(0, 0, -1)),
+		core.NewRay(core.NewVec3(-3, 0.3, -0.1), core.NewVec3(1, 0, 0.1)),
+		core.NewRay(core.NewVec3(2, 2, 2), core.NewVec3(-1, -1.2, -0.9)),
+		core.NewRay(core.NewVec3(2, 2, 2), core.NewVec3(1, 0, 0)),
+	}
+
+	assert.Equal(t, reference.BoundingBox(), indexed.BoundingBox())
+	for _, ray := range rays {
+		expected := reference.TestRay(ray, core.NewInterval(0, 10))
+		hit := indexed.TestRay(ray, core.NewInterval(0, 10))
+		assert.Equal(t, expected.Present(), hit.Present())
+		if expected.Present() {
+			assert.InDelta(t, expected.Value().Param, hit.Value().Param, 1e-6)
+			test.AssertInDeltaVec3(t, expected.Value().Normal, hit.Value().Normal, 1e-6)
+		}
+	}
+}
+
+func cubeMesh() geometries.Mesh {
+	soup := cubeSoup()
+	triangles := []geometries.Triangle{}
+	for _, face := range soup.Faces() {
+		triangles = append(triangles, geometries.NewTriangle(
+			soup.Positions()[face[0]], soup.Positions()[face[1]], soup.Positions()[face[2]]))
+	}
+	return geometries.NewMesh(triangles)
+}
+
+func TestIndexedMesh_BuildShouldSkipDegenerateFaces(t *testing.T) {
+	positions := []core.Vec3{core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0), core.NewVec3(2, 0, 0), core.NewVec3(0, 1, 0)}
+	mesh := geometries.NewIndexedMesh(positions, [][3]int{{0, 1, 2}, {0, 1, 3}}).Build()
+
+	assert.Equal(t, core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 1, 0)), mesh.BoundingBox())
+}
+
+func TestIndexedMesh_ShouldPanic_IfSettingsAreInvalid(t *testing.T) {
+	positions := []core.Vec3{core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0), core.NewVec3(0, 1, 0)}
+	mesh := geometries.NewIndexedMesh(positions, [][3]int{{0, 1, 2}})
+
+	assert.Panics(t, func() { geometries.NewIndexedMesh(positions, [][3]int{{0, 1, 3}}) })
+	assert.Panics(t, func() { mesh.WithNormals(make([]core.Vec3, 2)) })
+	assert.Panics(t, func() { mesh.WithUVs(make([]core.Vec2, 4)) })
+	assert.Panics(t, func() { mesh.Weld(0) })
+	assert.Panics(t, func() { mesh.Subdivide(-1) })
+	assert.Panics(t, func() { mesh.SmoothNormals(30, geometries.NormalWeighting(5)) })
+}
@@ -0,0 +1,87 @@
+package geometries_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinearBVH_ShouldFindClosestHit(t *testing.T) {
+	bvh := geometries.NewLinearBVH(sphereRow(50))
+	ray := core.NewRay(core.NewVec3(60, 0, 0), core.NewVec3(-1, 0, 0))
+
+	hit := bvh.TestRay(ray, core.NewInterval(0, core.Inf()))
+
+	assert.EqualValues(t, 10.5, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(1, 0, 0), hit.Value().Normal)
+}
+
+func TestLinearBVH_ShouldMatchPointerBVH(t *testing.T) {
+	generator := rand.New(rand.NewSource(42))
+	hittables := randomSpheres(generator, 500)
+	pointerBVH := geometries.BuildBVH(hittables)
+	linearBVH := geometries.FlattenBVH(pointerBVH)
+
+	assert.Equal(t, pointerBVH.BoundingBox(), linearBVH.BoundingBox())
+	assert.Equal(t, pointerBVH.Stats(), linearBVH.Stats())
+	for i := 0; i < 1000; i++ {
+		ray := randomRay(generator)
+		params := core.NewInterval(0.001, core.Inf())
+
+		assert.Equal(t, pointerBVH.TestRay(ray, params), linearBVH.TestRay(ray, params))
+	}
+}
+
+func TestLinearBVH_ShouldHandleEmptyInput(t *testing.T) {
+	bvh := geometries.NewLinearBVH([]geometries.Hittable{})
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0))
+
+	hit := bvh.TestRay(ray, core.NewInterval(0, core.Inf()))
+
+	assert.True(t, hit.Empty())
+}
+
+func BenchmarkBVH_PointerTree(b *testing.B) {
+	generator := rand.New(rand.NewSource(42))
+	bvh := geometries.BuildBVH(randomSpheres(generator, 10000))
+	benchmarkHittable(b, bvh, generator)
+}
+
+func BenchmarkBVH_LinearTree(b *testing.B) {
+	generator := rand.New(rand.NewSource(42))
+	bvh := geometries.NewLinearBVH(randomSpheres(generator, 10000))
+	benchmarkHittable(b, bvh, generator)
+}
+
+func benchmarkHittable(b *testing.B, hittable geometries.Hittable, generator *rand.Rand) {
+	rays := make([]core.Ray, 1024)
+	for i := range rays {
+		rays[i] = randomRay(generator)
+	}
+	params := core.NewInterval(0.001, core.Inf())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hittable.TestRay(rays[i%len(rays)], params)
+	}
+}
+
+func randomSpheres(generator *rand.Rand, count int) []geometries.Hittable {
+	spheres := []geometries.Hittable{}
+	for i := 0; i < count; i++ {
+		spheres = append(spheres, geometries.NewSphere(randomVec3(generator).Mul(100), 0.5+core.Real(generator.Float32())))
+	}
+	return spheres
+}
+
+func randomRay(generator *rand.Rand) core.Ray {
+	return core.NewRay(randomVec3(generator).Mul(100), randomVec3(generator))
+}
+
+// In [-1, 1) along each axis.
+func randomVec3(generator *rand.Rand) core.Vec3 {
+	return core.NewVec3(core.Real(generator.Float32()), core.Real(generator.Float32()), core.Real(generator.Float32())).Mul(2).Sub(core.NewVec3(1, 1, 1))
+}
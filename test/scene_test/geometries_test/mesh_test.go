@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,6 +28,15 @@ func TestMeshQuad_ShouldTestRay(t *testing.T) {
 	assert.Equal(t, core.NewVec3(0, 0, 1), hit.Value().Normal)
 }
 
+func TestMeshQuad_ShouldMapCornersToUnitSquareUVs(t *testing.T) {
+	quad := unitSquareXYQuad()
+	ray := core.NewRay(core.NewVec3(0.25, 0.75, 2), core.NewVec3(0, 0, -1))
+
+	hit := quad.TestRay(ray, core.NewInterval(0, core.Inf()))
+
+	test.AssertInDeltaVec2(t, core.NewVec2(0.25, 0.75), hit.Value().UV, core.Tolerance)
+}
+
 func unitSquareXYQuad() geometries.Mesh {
 	return geometries.NewQuad(
 		core.NewVec3(0, 0, 0),
@@ -33,3 +44,21 @@ func unitSquareXYQuad() geometries.Mesh {
 		core.NewVec3(1, 1, 0),
 		core.NewVec3(0, 1, 0))
 }
+
+func TestMeshQuad_ShouldSamplePointsUniformlyOverArea(t *testing.T) {
+	quad := unitSquareXYQuad()
+	origin := core.NewVec3(0.5, 0.5, 1)
+	randomizer := random.NewRandomGenerator()
+
+	for i := 0; i < 100; i++ {
+		sample := quad.Sample(origin, randomizer)
+
+		assert.InDelta(t, 0.5, sample.Point.X(), 0.5)
+		assert.InDelta(t, 0.5, sample.Point.Y(), 0.5)
+		assert.EqualValues(t, 0, sample.Point.Z())
+		assert.Equal(t, core.NewVec3(0, 0, 1), sample.Normal)
+		toPoint := sample.Point.Sub(origin)
+		expectedPDF := toPoint.LenSqr() * toPoint.Len() / core.Abs(toPoint.Z())
+		assert.InDelta(t, expectedPDF, sample.PDF, 1e-4)
+	}
+}
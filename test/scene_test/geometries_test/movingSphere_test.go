@@ -0,0 +1,46 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovingSphere_ShouldBeHitWhereItIsAtRayTime(t *testing.T) {
+	sphere := geometries.NewMovingSphere(core.NewVec3(0, 0, 0), core.NewVec3(0, 4, 0), 0, 1, 1)
+	origin := core.NewVec3(-5, 2, 0)
+	direction := core.NewVec3(1, 0, 0)
+
+	atStart := sphere.TestRay(core.NewRayAtTime(origin, direction, 0), core.NewInterval(0, 10))
+	halfway := sphere.TestRay(core.NewRayAtTime(origin, direction, 0.5), core.NewInterval(0, 10))
+
+	assert.True(t, atStart.Empty())
+	assert.EqualValues(t, 4, halfway.Value().Param)
+	assert.Equal(t, core.NewVec3(-1, 0, 0), halfway.Value().Normal)
+}
+
+func TestMovingSphere_ShouldStayAtEndPointsOutsideTimeInterval(t *testing.T) {
+	sphere := geometries.NewMovingSphere(core.NewVec3(0, 0, 0), core.NewVec3(0, 4, 0), 0, 1, 1)
+	direction := core.NewVec3(1, 0, 0)
+
+	before := sphere.TestRay(core.NewRayAtTime(core.NewVec3(-5, 0, 0), direction, -1), core.NewInterval(0, 10))
+	after := sphere.TestRay(core.NewRayAtTime(core.NewVec3(-5, 4, 0), direction, 2), core.NewInterval(0, 10))
+
+	assert.True(t, before.Present())
+	assert.True(t, after.Present())
+}
+
+func TestMovingSphere_BoundingBoxShouldCoverMotion(t *testing.T) {
+	sphere := geometries.NewMovingSphere(core.NewVec3(0, 0, 0), core.NewVec3(2, 4, 0), 0, 1, 1)
+
+	box := sphere.BoundingBox()
+
+	assert.Equal(t, core.NewVec3(-1, -1, -1), box.Min())
+	assert.Equal(t, core.NewVec3(3, 5, 1), box.Max())
+}
+
+func TestMovingSphere_ShouldPanic_WhenTimeIntervalIsEmpty(t *testing.T) {
+	assert.Panics(t, func() { geometries.NewMovingSphere(core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0), 1, 1, 1) })
+}
@@ -0,0 +1,101 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovingTransform_ShouldMoveHittableOverTime(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	moving := geometries.NewMovingTransform(sphere,
+		core.Identity(), core.Translation(core.NewVec3(0, 0, 4)), 1, 3)
+	origin := core.NewVec3(-5, 0, 2)
+	direction := core.NewVec3(1, 0, 0)
+
+	atStart := moving.TestRay(core.NewRayAtTime(origin, direction, 1), core.NewInterval(0, 10))
+	halfway := moving.TestRay(core.NewRayAtTime(origin, direction, 2), core.NewInterval(0, 10))
+
+	assert.True(t, atStart.Empty())
+	assert.InDelta(t, 4, halfway.Value().Param, core.Tolerance)
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 0, 2), halfway.Value().Point, core.Tolerance)
+}
+
+func TestMovingTransform_ShouldRotateAlongArc(t *testing.T) {
+	// An off-center sphere rotating by 90 degrees passes the diagonal halfway,
+	// blending the matrices entrywise would shrink it towards the axis
+	sphere := geometries.NewSphere(core.NewVec3(4, 0, 0), 1)
+	moving := geometries.NewMovingTransform(sphere,
+		core.Identity(), core.Rotation(90, core.NewVec3(0, 0, 1)), 0, 1)
+	diagonal := core.NewVec3(1, 1, 0).Normalize()
+
+	hit := moving.TestRay(core.NewRayAtTime(core.NewVec3(0, 0, 0), diagonal, 0.5), core.NewInterval(0, 10))
+
+	assert.InDelta(t, 3, hit.Value().Param, 1e-3)
+	test.AssertInDeltaVec3(t, diagonal.Mul(-1), hit.Value().Normal, 1e-3)
+}
+
+func TestMovingTransform_BoundingBoxShouldCoverMotion(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(4, 0, 0), 1)
+	moving := geometries.NewMovingTransform(sphere,
+		core.Identity(), core.Rotation(150, core.NewVec3(0, 0, 1)), 0, 1)
+
+	box := moving.BoundingBox()
+
+	for i := 0; i <= 100; i++ {
+		time := core.Real(i) / 100
+		center := core.Rotation(150*time, core.NewVec3(0, 0, 1)).TransformPoint(core.NewVec3(4, 0, 0))
+		sphereBox := geometries.NewSphere(center, 1).BoundingBox()
+		assert.Equal(t, box, box.Union(sphereBox))
+	}
+}
+
+func TestMovingTransform_BoundingBoxShouldCoverRotationWithScaling(t *testing.T) {
+	// A small sphere far from the axis strays from the sampled boxes between the samples
+	sphere := geometries.NewSphere(core.NewVec3(100, 0, 0), 0.001)
+	end := core.Rotation(170, core.NewVec3(0, 0, 1)).Mul(core.Scaling(core.NewVec3(3, 3, 3)))
+	moving := geometries.NewMovingTransform(sphere, core.Identity(), end, 0, 1)
+
+	box := moving.BoundingBox()
+
+	for i := 0; i <= 1000; i++ {
+		time := core.Real(i) / 1000
+		scale := 1 + 2*time
+		center := core.Rotation(170*time, core.NewVec3(0, 0, 1)).TransformPoint(core.NewVec3(100*scale, 0, 0))
+		sphereBox := geometries.NewSphere(center, 0.001*scale).BoundingBox()
+		assert.Equal(t, box, box.Union(sphereBox))
+	}
+}
+
+func TestMovingTransform_ShouldReturnEndPointsExactly(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	start := core.Translation(core.NewVec3(0.1, 0.2, 0.3)).Mul(core.Rotation(33, core.NewVec3(1, 2, 3)))
+	end := core.Translation(core.NewVec3(5, 0, 0)).Mul(core.Scaling(core.NewVec3(2, 1, 0.5)))
+	moving := geometries.NewMovingTransform(sphere, start, end, 0, 1)
+	ray := core.NewRay(core.NewVec3(-5, 0.3, 0.1), core.NewVec3(1, 0, 0))
+
+	for _, endPoint := range []struct {
+		time      core.Real
+		transform geometries.Transform
+	}{{-1, geometries.NewTransform(sphere, start)}, {0, geometries.NewTransform(sphere, start)}, {1, geometries.NewTransform(sphere, end)}, {2, geometries.NewTransform(sphere, end)}} {
+		timedRay := core.NewRayAtTime(ray.Origin(), ray.Direction(), endPoint.time)
+		assert.Equal(t, endPoint.transform.TestRay(timedRay, core.NewInterval(0, 20)), moving.TestRay(timedRay, core.NewInterval(0, 20)))
+	}
+}
+
+func TestMovingTransform_ShouldPanic_IfMatrixHasShear(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	shear := core.Rotation(45, core.NewVec3(0, 0, 1)).Mul(core.Scaling(core.NewVec3(2, 1, 1))).Mul(core.Rotation(-30, core.NewVec3(0, 0, 1)))
+
+	assert.Panics(t, func() { geometries.NewMovingTransform(sphere, core.Identity(), shear, 0, 1) })
+	assert.Panics(t, func() { geometries.NewMovingTransform(sphere, shear, core.Identity(), 0, 1) })
+}
+
+func TestMovingTransform_ShouldPanic_WhenTimeIntervalIsEmpty(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+
+	assert.Panics(t, func() { geometries.NewMovingTransform(sphere, core.Identity(), core.Identity(), 1, 0) })
+}
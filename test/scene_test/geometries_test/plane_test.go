@@ -0,0 +1,52 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlane_ShouldBeHitAnywhere(t *testing.T) {
+	plane := geometries.NewPlane(core.NewVec3(0, -1, 0), core.NewVec3(0, 2, 0))
+	ray := core.NewRay(core.NewVec3(100, 1, -50), core.NewVec3(0, -1, 0))
+
+	hit := plane.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.EqualValues(t, 2, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(100, -1, -50), hit.Value().Point)
+	assert.Equal(t, core.NewVec3(0, 1, 0), hit.Value().Normal)
+}
+
+func TestPlane_ShouldMissParallelRay(t *testing.T) {
+	plane := geometries.NewPlane(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0))
+	ray := core.NewRay(core.NewVec3(0, 1, 0), core.NewVec3(1, 0, 0))
+
+	hit := plane.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.True(t, hit.Empty())
+}
+
+func TestPlane_UVShouldMeasureDistanceAlongPlane(t *testing.T) {
+	plane := geometries.NewPlane(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0))
+	down := core.NewVec3(0, -1, 0)
+
+	origin := plane.TestRay(core.NewRay(core.NewVec3(0, 1, 0), down), core.NewInterval(0, 10)).Value()
+	shifted := plane.TestRay(core.NewRay(core.NewVec3(3, 1, 4), down), core.NewInterval(0, 10)).Value()
+
+	offset := shifted.UV.Sub(origin.UV)
+	assert.InDelta(t, 5, core.Hypot(offset.X(), offset.Y()), 1e-5)
+}
+
+func TestPlane_BoundingBoxShouldBeFlat_IfOrthogonalToAxis(t *testing.T) {
+	plane := geometries.NewPlane(core.NewVec3(1, 2, 3), core.NewVec3(0, 1, 0))
+	tilted := geometries.NewPlane(core.NewVec3(1, 2, 3), core.NewVec3(0, 1, 1))
+
+	box := plane.BoundingBox()
+
+	assert.EqualValues(t, 2, box.Min().Y())
+	assert.EqualValues(t, 2, box.Max().Y())
+	assert.Equal(t, -core.Inf(), box.Min().X())
+	assert.Equal(t, core.NewInfiniteBox(), tilted.BoundingBox())
+}
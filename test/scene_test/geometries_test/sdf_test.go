@@ -0,0 +1,73 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func sphereSDF() geometries.SDF {
+	return geometries.NewSDF(geometries.SphereDistance(core.NewVec3(0, 0, 0), 1),
+		core.NewBox(core.NewVec3(-1, -1, -1), core.NewVec3(1, 1, 1)))
+}
+
+func TestSDF_ShouldMatchAnalyticSphere(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	ray := core.NewRay(core.NewVec3(-4, 0.3, 0.2), core.NewVec3(2, 0, 0.1))
+
+	expected := sphere.TestRay(ray, core.NewInterval(0, 10)).Value()
+	hit := sphereSDF().TestRay(ray, core.NewInterval(0, 10)).Value()
+
+	assert.InDelta(t, expected.Param, hit.Param, 1e-4)
+	test.AssertInDeltaVec3(t, expected.Normal, hit.Normal, 1e-3)
+}
+
+func TestSDF_ShouldBeHitFromInside(t *testing.T) {
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0))
+
+	hit := sphereSDF().TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 1, hit.Value().Param, 1e-4)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 1, 0), hit.Value().Normal, 1e-3)
+}
+
+func TestSDF_ShouldMissRayPassingBy(t *testing.T) {
+	ray := core.NewRay(core.NewVec3(-4, 0.9, 0.9), core.NewVec3(1, 0, 0))
+
+	hit := sphereSDF().TestRay(ray, core.NewInterval(0, 10))
+
+	assert.True(t, hit.Empty())
+}
+
+func TestSDF_ShouldRespectParamInterval(t *testing.T) {
+	ray := core.NewRay(core.NewVec3(-4, 0, 0), core.NewVec3(1, 0, 0))
+
+	before := sphereSDF().TestRay(ray, core.NewInterval(0, 2.9))
+	behind := sphereSDF().TestRay(ray, core.NewInterval(3.5, 10))
+
+	assert.True(t, before.Empty())
+	assert.InDelta(t, 5, behind.Value().Param, 1e-4)
+}
+
+func TestSDF_ShouldLiveInBVHWithOtherHittables(t *testing.T) {
+	box := geometries.NewSDF(geometries.BoxDistance(core.NewVec3(5, 0, 0), core.NewVec3(1, 1, 1)),
+		core.NewBox(core.NewVec3(4, -1, -1), core.NewVec3(6, 1, 1)))
+	bvh := geometries.BuildBVH([]geometries.Hittable{geometries.NewSphere(core.NewVec3(0, 0, 0), 1), box})
+	ray := core.NewRay(core.NewVec3(10, 0.5, 0.5), core.NewVec3(-1, 0, 0))
+
+	hit := bvh.TestRay(ray, core.NewInterval(0, 20))
+
+	assert.InDelta(t, 4, hit.Value().Param, 1e-4)
+	test.AssertInDeltaVec3(t, core.NewVec3(1, 0, 0), hit.Value().Normal, 1e-3)
+}
+
+func TestSDF_ShouldValidateSettings(t *testing.T) {
+	assert.Panics(t, func() { geometries.SDFMaxSteps(0) })
+	assert.Panics(t, func() { geometries.SDFStepScale(1.5) })
+	assert.Panics(t, func() {
+		geometries.NewSDF(geometries.SphereDistance(core.NewVec3(0, 0, 0), 1), core.NewEmptyBox())
+	})
+}
@@ -0,0 +1,67 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpans_ShouldFindSpanInsideSphere_BehindRayOrigin(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	ray := core.NewRay(core.NewVec3(5, 0, 0), core.NewVec3(1, 0, 0))
+
+	spans := geometries.Spans(sphere, ray)
+
+	assert.Len(t, spans, 1)
+	assert.EqualValues(t, -6, spans[0].Entry.Param)
+	assert.EqualValues(t, -4, spans[0].Exit.Param)
+}
+
+func TestSpans_ShouldFindAllSpansOfTorus(t *testing.T) {
+	torus := geometries.NewTorus(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0), 2, 0.5)
+	ray := core.NewRay(core.NewVec3(-5, 0, 0), core.NewVec3(1, 0, 0))
+
+	spans := geometries.Spans(torus, ray)
+
+	assert.Len(t, spans, 2)
+	assert.InDelta(t, 2.5, spans[0].Entry.Param, 1e-4)
+	assert.InDelta(t, 3.5, spans[0].Exit.Param, 1e-4)
+	assert.InDelta(t, 6.5, spans[1].Entry.Param, 1e-4)
+	assert.InDelta(t, 7.5, spans[1].Exit.Param, 1e-4)
+}
+
+func TestSpans_ShouldFindSpanOfEachPart_OfNonConvexHittable(t *testing.T) {
+	spheres := geometries.NewLinearBVH([]geometries.Hittable{
+		geometries.NewSphere(core.NewVec3(-2, 0, 0), 1),
+		geometries.NewSphere(core.NewVec3(2, 0, 0), 1),
+	})
+	ray := core.NewRay(core.NewVec3(-5, 0, 0), core.NewVec3(1, 0, 0))
+
+	spans := geometries.Spans(spheres, ray)
+
+	assert.Len(t, spans, 2)
+	assert.InDelta(t, 2, spans[0].Entry.Param, 1e-4)
+	assert.InDelta(t, 4, spans[0].Exit.Param, 1e-4)
+	assert.InDelta(t, 6, spans[1].Entry.Param, 1e-4)
+	assert.InDelta(t, 8, spans[1].Exit.Param, 1e-4)
+}
+
+func TestSpans_ShouldFindNoSpans_IfRayMisses(t *testing.T) {
+	box := geometries.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 1, 1))
+	ray := core.NewRay(core.NewVec3(-1, 2, 0), core.NewVec3(1, 0, 0))
+
+	assert.Empty(t, geometries.Spans(box, ray))
+}
+
+func TestSpans_ShouldFindSpanOfDistantSphere(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, -3000), 1)
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(0, 0, -1))
+
+	spans := geometries.Spans(sphere, ray)
+
+	assert.Len(t, spans, 1)
+	assert.InDelta(t, 2999, spans[0].Entry.Param, 1e-3)
+	assert.InDelta(t, 3001, spans[0].Exit.Param, 1e-3)
+}
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -76,3 +78,81 @@ func TestSphere_ShouldComputeBoundingBox(t *testing.T) {
 	expectedBBox := core.NewBox(core.NewVec3(-2, -2, -2), core.NewVec3(2, 2, 2))
 	assert.Equal(t, expectedBBox, bbox)
 }
+
+func TestSphere_ShouldSamplePointsVisibleFromOrigin(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+	origin := core.NewVec3(0, 0, 10)
+	expectedPDF := 1 / (2 * core.Pi * (1 - core.Sqrt(1-0.04)))
+	randomizer := random.NewRandomGenerator()
+
+	for i := 0; i < 100; i++ {
+		sample := sphere.Sample(origin, randomizer)
+
+		assert.InDelta(t, 2, sample.Point.Len(), 1e-4)
+		assert.Equal(t, sample.Point.Div(2), sample.Normal)
+		// Points seen from the origin lie on the cap above the tangent plane z = r^2/d
+		assert.GreaterOrEqual(t, sample.Point.Z(), core.Real(0.4-1e-4))
+		assert.InDelta(t, expectedPDF, sample.PDF, 1e-2)
+	}
+}
+
+func TestSphere_PDFShouldMatchSampledPDF(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+	origin := core.NewVec3(0, 0, 10)
+	sample := sphere.Sample(origin, random.NewRandomGenerator())
+	ray := core.NewRay(origin, sample.Point.Sub(origin))
+
+	hit := sphere.TestRay(ray, core.NewInterval(0, core.Inf()))
+
+	assert.InDelta(t, 1, hit.Value().Param, 1e-4)
+	assert.Equal(t, sample.PDF, sphere.PDF(origin, hit.Value()))
+}
+
+func TestSphere_ShouldSampleWholeSurface_IfOriginInside(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+
+	sample := sphere.Sample(core.NewVec3(0, 0, 0), random.NewRandomGenerator())
+
+	assert.InDelta(t, 2, sample.Point.Len(), 1e-4)
+	assert.InDelta(t, 1/(4*core.Pi), sample.PDF, 1e-4)
+}
+
+func TestSphere_ShouldMapHitToSphericalUV(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+	testCases := []struct {
+		origin     core.Vec3
+		expectedUV core.Vec2
+	}{
+		{core.NewVec3(4, 0, 0), core.NewVec2(0.5, 0.5)},
+		{core.NewVec3(0, 0, 4), core.NewVec2(0.25, 0.5)},
+		{core.NewVec3(0, 0, -4), core.NewVec2(0.75, 0.5)},
+		{core.NewVec3(0, 4, 0), core.NewVec2(0.5, 1)},
+		{core.NewVec3(0, -4, 0), core.NewVec2(0.5, 0)},
+	}
+
+	for _, testCase := range testCases {
+		ray := core.NewRay(testCase.origin, testCase.origin.Mul(-1))
+		hit := sphere.TestRay(ray, core.NewInterval(0, 10))
+		test.AssertInDeltaVec2(t, testCase.expectedUV, hit.Value().UV, core.Tolerance)
+	}
+}
+
+func TestSphere_TangentFrameShouldFollowUV(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+	ray := core.NewRay(core.NewVec3(4, 0, 0), core.NewVec3(-1, 0, 0))
+
+	hit := sphere.TestRay(ray, core.NewInterval(0, 10))
+
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 0, -1), hit.Value().Tangent, core.Tolerance)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 1, 0), hit.Value().Bitangent, core.Tolerance)
+}
+
+func TestSphere_ShouldHaveTangentFrameAtPoles(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 2)
+	ray := core.NewRay(core.NewVec3(0, 4, 0), core.NewVec3(0, -1, 0))
+
+	hit := sphere.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 1, hit.Value().Tangent.Len(), core.Tolerance)
+	assert.InDelta(t, 0, hit.Value().Tangent.Dot(hit.Value().Normal), core.Tolerance)
+}
@@ -0,0 +1,69 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTorus_ShouldBeHitOnOuterSide(t *testing.T) {
+	torus := geometries.NewTorus(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0), 2, 0.5)
+	ray := core.NewRay(core.NewVec3(-5, 0, 0), core.NewVec3(2, 0, 0))
+
+	hit := torus.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 1.25, hit.Value().Param, 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 0, 0), hit.Value().Normal, 1e-4)
+	assert.InDelta(t, 0, hit.Value().UV.Y(), 1e-4)
+}
+
+func TestTorus_ShouldPassThroughHole(t *testing.T) {
+	torus := geometries.NewTorus(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0), 2, 0.5)
+	ray := core.NewRay(core.NewVec3(0, 5, 0), core.NewVec3(0, -1, 0))
+
+	hit := torus.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.True(t, hit.Empty())
+}
+
+func TestTorus_ShouldBeHitOnTopOfTube(t *testing.T) {
+	torus := geometries.NewTorus(core.NewVec3(1, 1, 1), core.NewVec3(0, 0, 1), 2, 0.5)
+	ray := core.NewRay(core.NewVec3(3, 1, 5), core.NewVec3(0, 0, -1))
+
+	hit := torus.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 3.5, hit.Value().Param, 1e-4)
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 0, 1), hit.Value().Normal, 1e-4)
+	assert.InDelta(t, 0.25, hit.Value().UV.Y(), 1e-4)
+}
+
+func TestTorus_ShouldBeHitAccurately_FromFarAway(t *testing.T) {
+	torus := geometries.NewTorus(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0), 2, 0.5)
+	ray := core.NewRay(core.NewVec3(-1000, 0, 0), core.NewVec3(1, 0, 0))
+
+	hit := torus.TestRay(ray, core.NewInterval(0, 2000))
+
+	assert.InDelta(t, -2.5, hit.Value().Point.X(), 1e-3)
+}
+
+func TestTorus_ShouldBeHitFromInsideTube(t *testing.T) {
+	torus := geometries.NewTorus(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0), 2, 0.5)
+	ray := core.NewRay(core.NewVec3(2, 0, 0), core.NewVec3(1, 0, 0))
+
+	hit := torus.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 0.5, hit.Value().Param, 1e-4)
+	test.AssertInDeltaVec3(t, core.NewVec3(1, 0, 0), hit.Value().Normal, 1e-4)
+}
+
+func TestTorus_ShouldHaveTightBoundingBox(t *testing.T) {
+	torus := geometries.NewTorus(core.NewVec3(0, 0, 0), core.NewVec3(0, 1, 0), 2, 0.5)
+
+	box := torus.BoundingBox()
+
+	test.AssertInDeltaVec3(t, core.NewVec3(-2.5, -0.5, -2.5), box.Min(), 1e-5)
+	test.AssertInDeltaVec3(t, core.NewVec3(2.5, 0.5, 2.5), box.Max(), 1e-5)
+}
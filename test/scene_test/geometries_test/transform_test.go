@@ -0,0 +1,86 @@
+package geometries_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransform_ShouldTranslateHittable(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	translated := geometries.NewTranslation(sphere, core.NewVec3(5, 0, 0))
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0))
+
+	hit := translated.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.EqualValues(t, 4, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(4, 0, 0), hit.Value().Point)
+	assert.Equal(t, core.NewVec3(-1, 0, 0), hit.Value().Normal)
+}
+
+func TestTransform_ShouldKeepHitParameterForScaledHittable(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	scaled := geometries.NewScaling(sphere, core.NewVec3(2, 2, 2))
+	ray := core.NewRay(core.NewVec3(-4, 0, 0), core.NewVec3(2, 0, 0))
+
+	hit := scaled.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.EqualValues(t, 1, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(-2, 0, 0), hit.Value().Point)
+}
+
+func TestTransform_ShouldRotateTangentFrame(t *testing.T) {
+	sphere := geometries.NewSphere(core.NewVec3(0, 0, 0), 1)
+	rotated := geometries.NewRotation(sphere, 90, core.NewVec3(0, 0, 1))
+	ray := core.NewRay(core.NewVec3(0, 4, 0), core.NewVec3(0, -1, 0))
+
+	hit := rotated.TestRay(ray, core.NewInterval(0, 10))
+
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 0, -1), hit.Value().Tangent, core.Tolerance)
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 0, 0), hit.Value().Bitangent, core.Tolerance)
+}
+
+func TestTransform_ShouldTransformNormalsWithInverseTranspose(t *testing.T) {
+	// The diagonal plane x + y = 1 squashed along Y keeps the normal perpendicular to the surface
+	triangle := geometries.NewTriangle(core.NewVec3(1, 0, -1), core.NewVec3(0, 1, -1), core.NewVec3(0, 1, 1))
+	squashed := geometries.NewScaling(triangle, core.NewVec3(1, 0.5, 1))
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(1, 0.5, 0))
+
+	hit := squashed.TestRay(ray, core.NewInterval(0, 10))
+
+	test.AssertInDeltaVec3(t, core.NewVec3(1, 2, 0).Normalize(), hit.Value().Normal, core.Tolerance)
+}
+
+func TestTransform_ShouldComputeWorldBoundingBox(t *testing.T) {
+	quad := unitSquareXYQuad()
+	rotated := geometries.NewRotation(quad, 90, core.NewVec3(1, 0, 0))
+
+	bbox := rotated.BoundingBox()
+
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 0, 0), bbox.Min(), core.Tolerance)
+	test.AssertInDeltaVec3(t, core.NewVec3(1, 0, 1), bbox.Max(), core.Tolerance)
+}
+
+func TestTransform_ShouldInstanceSameMeshInBVH(t *testing.T) {
+	quad := unitSquareXYQuad()
+	instances := []geometries.Hittable{}
+	for i := 0; i < 100; i++ {
+		offset := core.NewVec3(0, 0, -core.Real(i))
+		instances = append(instances, geometries.NewTranslation(quad, offset))
+	}
+	bvh := geometries.BuildBVH(instances)
+	ray := core.NewRay(core.NewVec3(0.5, 0.5, 1), core.NewVec3(0, 0, -1))
+
+	hit := bvh.TestRay(ray, core.NewInterval(2.5, 100))
+
+	assert.EqualValues(t, 3, hit.Value().Param)
+}
+
+func TestTransform_ShouldPanic_IfMatrixNotInvertible(t *testing.T) {
+	assert.Panics(t, func() {
+		geometries.NewScaling(unitSquareXYQuad(), core.NewVec3(1, 1, 0))
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/geometries"
+	"github.com/Shamanskiy/go-ray-tracer/test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,9 +44,54 @@ func TestTriangle_ShouldReturnHit(t *testing.T) {
 	hit := triangle.TestRay(ray, core.NewInterval(0, 10))
 
 	assert.InDelta(t, 1./3, hit.Value().Param, core.Tolerance)
-	assert.Equal(t, core.NewVec3(1./3, 1./3, 1./3), hit.Value().Point)
+	test.AssertInDeltaVec3(t, core.NewVec3(1./3, 1./3, 1./3), hit.Value().Point, core.Tolerance)
 	normValue := core.Sqrt(3) / 3
-	assert.Equal(t, core.NewVec3(normValue, normValue, normValue), hit.Value().Normal)
+	test.AssertInDeltaVec3(t, core.NewVec3(normValue, normValue, normValue), hit.Value().Normal, core.Tolerance)
+}
+
+func TestTriangle_ShouldHitFromBothSides(t *testing.T) {
+	triangle := xyzTriangle()
+	ray := core.NewRay(core.NewVec3(1, 1, 1), core.NewVec3(-1, -1, -1))
+
+	hit := triangle.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 2./3, hit.Value().Param, core.Tolerance)
+}
+
+func TestTriangle_ShouldHitTinyTriangles(t *testing.T) {
+	triangle := geometries.NewTriangle(core.NewVec3(0, 0, 0), core.NewVec3(1e-3, 0, 0), core.NewVec3(0, 1e-3, 0))
+	ray := core.NewRay(core.NewVec3(2e-4, 2e-4, 1), core.NewVec3(0, 0, -1))
+
+	hit := triangle.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.InDelta(t, 1, hit.Value().Param, core.Tolerance)
+}
+
+func TestTriangle_RayInTrianglePlaneShouldMiss(t *testing.T) {
+	triangle := geometries.NewTriangle(core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0), core.NewVec3(0, 1, 0))
+	ray := core.NewRay(core.NewVec3(-1, 0.2, 0), core.NewVec3(1, 0, 0))
+
+	hit := triangle.TestRay(ray, core.NewInterval(0, 10))
+
+	assert.True(t, hit.Empty())
+}
+
+// Rays through the shared edge of two triangles must hit at least one of them
+func TestTriangle_ShouldBeWatertightAlongSharedEdges(t *testing.T) {
+	a, b := core.NewVec3(1451.4874, 1318.6646, 1887.2073), core.NewVec3(1446.3799, 1312.8768, 1888.7975)
+	c, d := core.NewVec3(1446.992, 1310.5162, 1895.8561), core.NewVec3(1441.8844, 1304.7285, 1897.4463)
+	first, second := geometries.NewTriangle(a, b, c), geometries.NewTriangle(c, b, d)
+	origin := core.NewVec3(16.340715, 6.9869714, 19.207203)
+
+	for i := 1; i < 1000; i++ {
+		target := b.Add(c.Sub(b).Mul(core.Real(i) / 1000))
+		ray := core.NewRay(origin, target.Sub(origin))
+		params := core.NewInterval(0, 2)
+
+		hit := first.TestRay(ray, params).Present() || second.TestRay(ray, params).Present()
+
+		assert.True(t, hit, "ray %d slipped through the edge", i)
+	}
 }
 
 func xyzTriangle() geometries.Triangle {
@@ -56,3 +103,52 @@ func xyzTriangle() geometries.Triangle {
 		core.NewVec3(1, 1, 1),
 		core.NewVec3(1, 1, 1))
 }
+
+func TestTriangle_ShouldSampleVertex_WhenNotRandom(t *testing.T) {
+	triangle := geometries.NewTriangle(core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0), core.NewVec3(0, 1, 0))
+	origin := core.NewVec3(0, 0, 2)
+
+	sample := triangle.Sample(origin, random.NewFakeRandomGenerator())
+
+	assert.Equal(t, core.NewVec3(0, 0, 0), sample.Point)
+	assert.Equal(t, core.NewVec3(0, 0, 1), sample.Normal)
+	// Area density 1/0.5, squared distance 4, normal incidence
+	assert.EqualValues(t, 8, sample.PDF)
+}
+
+func TestTriangle_ShouldSamplePointsOnTriangle(t *testing.T) {
+	triangle := xyzTriangle()
+	origin := core.NewVec3(0, 0, 0)
+	randomizer := random.NewRandomGenerator()
+
+	for i := 0; i < 100; i++ {
+		sample := triangle.Sample(origin, randomizer)
+		ray := core.NewRay(origin, sample.Point)
+
+		hit := triangle.TestRay(ray, core.NewInterval(0, 2))
+
+		assert.InDelta(t, 1, hit.Value().Param, 1e-4)
+		assert.InDelta(t, sample.PDF, triangle.PDF(origin, hit.Value()), 1e-3)
+	}
+}
+
+func TestTriangle_ShouldInterpolateUVs(t *testing.T) {
+	triangle := xyzTriangle().WithUVs(core.NewVec2(0, 0), core.NewVec2(1, 0), core.NewVec2(0.5, 1))
+	ray := core.NewRay(core.NewVec3(0, 0, 0), core.NewVec3(1, 1, 1))
+
+	hit := triangle.TestRay(ray, core.NewInterval(0, 10))
+
+	test.AssertInDeltaVec2(t, core.NewVec2(0.5, 1./3), hit.Value().UV, core.Tolerance)
+}
+
+func TestTriangle_TangentFrameShouldFollowUVDerivatives(t *testing.T) {
+	// U grows along Y and V grows along -X
+	triangle := geometries.NewTriangle(core.NewVec3(0, 0, 0), core.NewVec3(1, 0, 0), core.NewVec3(0, 1, 0)).
+		WithUVs(core.NewVec2(0, 1), core.NewVec2(0, 0), core.NewVec2(1, 1))
+	ray := core.NewRay(core.NewVec3(0.25, 0.25, 1), core.NewVec3(0, 0, -1))
+
+	hit := triangle.TestRay(ray, core.NewInterval(0, 10))
+
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 1, 0), hit.Value().Tangent, core.Tolerance)
+	test.AssertInDeltaVec3(t, core.NewVec3(-1, 0, 0), hit.Value().Bitangent, core.Tolerance)
+}
@@ -0,0 +1,79 @@
+package importers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/importers"
+	"github.com/stretchr/testify/assert"
+)
+
+func densityGridFile(magic string, resolution [3]uint32, boundsMax [3]float32, densities []float32) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(magic)
+	binary.Write(&buffer, binary.LittleEndian, resolution)
+	binary.Write(&buffer, binary.LittleEndian, [3]float32{0, 0, 0})
+	binary.Write(&buffer, binary.LittleEndian, boundsMax)
+	binary.Write(&buffer, binary.LittleEndian, densities)
+	return buffer.Bytes()
+}
+
+func TestDensityGrid_ShouldReadVoxels(t *testing.T) {
+	data := densityGridFile("GRID", [3]uint32{2, 1, 1}, [3]float32{2, 1, 1}, []float32{1, 3})
+
+	grid, err := importers.ReadDensityGrid(bytes.NewReader(data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(2, 1, 1)), grid.Bounds())
+	assert.InDelta(t, 1, grid.DensityAt(core.NewVec3(0.5, 0.5, 0.5)), core.Tolerance)
+	assert.InDelta(t, 2, grid.DensityAt(core.NewVec3(1, 0.5, 0.5)), core.Tolerance)
+	assert.EqualValues(t, 3, grid.MaxDensity())
+}
+
+func TestDensityGrid_ShouldLoadFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cloud.grid")
+	data := densityGridFile("GRID", [3]uint32{1, 1, 1}, [3]float32{1, 1, 1}, []float32{0.5})
+	assert.NoError(t, os.WriteFile(filename, data, 0o644))
+
+	grid, err := importers.LoadDensityGridFile(filename)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0.5, grid.MaxDensity())
+}
+
+func TestDensityGrid_ShouldReturnError_IfMagicInvalid(t *testing.T) {
+	data := densityGridFile("GRIT", [3]uint32{1, 1, 1}, [3]float32{1, 1, 1}, []float32{1})
+
+	_, err := importers.ReadDensityGrid(bytes.NewReader(data))
+
+	assert.ErrorContains(t, err, "magic")
+}
+
+func TestDensityGrid_ShouldReturnError_IfDensitiesTruncated(t *testing.T) {
+	data := densityGridFile("GRID", [3]uint32{2, 2, 2}, [3]float32{1, 1, 1}, []float32{1, 2, 3})
+
+	_, err := importers.ReadDensityGrid(bytes.NewReader(data))
+
+	assert.ErrorContains(t, err, "densities")
+}
+
+func TestDensityGrid_ShouldReturnError_IfHeaderInvalid(t *testing.T) {
+	zeroResolution := densityGridFile("GRID", [3]uint32{0, 1, 1}, [3]float32{1, 1, 1}, nil)
+	flatBounds := densityGridFile("GRID", [3]uint32{1, 1, 1}, [3]float32{1, 0, 1}, []float32{1})
+	negativeDensity := densityGridFile("GRID", [3]uint32{1, 1, 1}, [3]float32{1, 1, 1}, []float32{-1})
+
+	for _, data := range [][]byte{zeroResolution, flatBounds, negativeDensity} {
+		_, err := importers.ReadDensityGrid(bytes.NewReader(data))
+		assert.Error(t, err)
+	}
+}
+
+func TestDensityGrid_ShouldReturnError_IfFileMissing(t *testing.T) {
+	_, err := importers.LoadDensityGridFile(filepath.Join(t.TempDir(), "missing.grid"))
+
+	assert.Error(t, err)
+}
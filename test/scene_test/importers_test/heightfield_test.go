@@ -0,0 +1,67 @@
+package importers_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/importers"
+	"github.com/stretchr/testify/assert"
+)
+
+var TERRAIN_BOUNDS = core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(2, 10, 1))
+
+// Black on the left, white on the right
+func rampPNG(t *testing.T) []byte {
+	img := image.NewGray16(image.Rect(0, 0, 3, 2))
+	for y := 0; y < 2; y++ {
+		img.SetGray16(0, y, color.Gray16{Y: 0})
+		img.SetGray16(1, y, color.Gray16{Y: 0x8000})
+		img.SetGray16(2, y, color.Gray16{Y: 0xffff})
+	}
+	var buffer bytes.Buffer
+	assert.NoError(t, png.Encode(&buffer, img))
+	return buffer.Bytes()
+}
+
+func TestHeightfield_ShouldReadGrayscalePNG(t *testing.T) {
+	field, err := importers.ReadHeightfield(bytes.NewReader(rampPNG(t)), TERRAIN_BOUNDS)
+
+	assert.NoError(t, err)
+	assert.Equal(t, core.NewVec3(0, 0, 0), field.BoundingBox().Min())
+	assert.Equal(t, core.NewVec3(2, 10, 1), field.BoundingBox().Max())
+
+	ray := core.NewRay(core.NewVec3(1, 20, 0.5), core.NewVec3(0, -1, 0))
+	hit := field.TestRay(ray, core.NewInterval(0, 30))
+	assert.InDelta(t, 15, hit.Value().Param, 1e-3)
+}
+
+func TestHeightfield_ShouldLoadFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "terrain.png")
+	assert.NoError(t, os.WriteFile(filename, rampPNG(t), 0o644))
+
+	field, err := importers.LoadHeightfieldFile(filename, TERRAIN_BOUNDS)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, field)
+}
+
+func TestHeightfield_ShouldReturnError_IfNotAnImage(t *testing.T) {
+	_, err := importers.ReadHeightfield(bytes.NewReader([]byte("not an image")), TERRAIN_BOUNDS)
+
+	assert.Error(t, err)
+}
+
+func TestHeightfield_ShouldReturnError_IfImageTooSmall(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.NoError(t, png.Encode(&buffer, image.NewGray(image.Rect(0, 0, 1, 4))))
+
+	_, err := importers.ReadHeightfield(&buffer, TERRAIN_BOUNDS)
+
+	assert.ErrorContains(t, err, "2x2")
+}
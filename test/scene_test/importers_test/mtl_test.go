@@ -0,0 +1,147 @@
+package importers_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/importers"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/stretchr/testify/assert"
+)
+
+const materialLibrary = `
+newmtl matt
+Kd 0.5 0.5 0.5
+
+newmtl mirror
+Kd 0 0 0
+Ks 0.8 0.8 0.8
+Ns 1000
+
+newmtl glass
+Ni 1.5
+d 0.1
+Tf 0.9 1 0.9
+
+newmtl lamp
+Ke 4 4 4
+`
+
+func TestMTL_ShouldParseParameters(t *testing.T) {
+	library, err := importers.ReadMTL(strings.NewReader(materialLibrary))
+
+	assert.NoError(t, err)
+	assert.Len(t, library, 4)
+	assert.Equal(t, color.GrayMedium, library["matt"].Diffuse)
+	assert.Equal(t, color.GrayLight, library["mirror"].Specular)
+	assert.EqualValues(t, 1000, library["mirror"].Shininess)
+	assert.EqualValues(t, 1.5, library["glass"].RefractionIndex)
+	assert.EqualValues(t, 0.1, library["glass"].Dissolve)
+	assert.Equal(t, color.New(4, 4, 4), library["lamp"].Emissive)
+}
+
+func TestMTL_ShouldMapToMaterials(t *testing.T) {
+	library, err := importers.ReadMTL(strings.NewReader(materialLibrary))
+	assert.NoError(t, err)
+
+	assert.Equal(t, materials.NewDiffusive(color.GrayMedium, randomizer), library["matt"].ToMaterial(randomizer))
+	assert.IsType(t, materials.Reflective{}, library["mirror"].ToMaterial(randomizer))
+	assert.Equal(t, materials.NewTransparent(1.5, color.New(0.9, 1, 0.9), randomizer), library["glass"].ToMaterial(randomizer))
+	assert.Equal(t, materials.NewDiffusiveLight(color.New(4, 4, 4), 1), library["lamp"].ToMaterial(randomizer))
+}
+
+func TestMTL_ShouldReturnError_IfStatementBeforeNewMaterial(t *testing.T) {
+	_, err := importers.ReadMTL(strings.NewReader("Kd 1 1 1"))
+
+	assert.ErrorContains(t, err, "line 1")
+}
+
+func TestMTL_ShouldReturnError_IfColorMalformed(t *testing.T) {
+	_, err := importers.ReadMTL(strings.NewReader("newmtl bad\nKd 1 x 1"))
+
+	assert.ErrorContains(t, err, "line 2")
+}
+
+const pbrMaterialLibrary = `
+newmtl paint
+Kd 1 0 0
+Pr 0.3
+Pm 1
+Pc 0.5
+Pcr 0.2
+Ps 0.1
+
+newmtl textured
+Kd 1 1 1
+map_Kd -s 2 2 1 albedo.png
+map_Pr roughness.png
+norm normal.png
+`
+
+func TestMTL_ShouldParsePBRParameters(t *testing.T) {
+	library, err := importers.ReadMTL(strings.NewReader(pbrMaterialLibrary))
+
+	assert.NoError(t, err)
+	paint := library["paint"]
+	assert.True(t, paint.PBR)
+	assert.EqualValues(t, 0.3, paint.Roughness)
+	assert.EqualValues(t, 1, paint.Metallic)
+	assert.EqualValues(t, 0.5, paint.Clearcoat)
+	assert.EqualValues(t, 0.2, paint.ClearcoatRoughness)
+	assert.EqualValues(t, 0.1, paint.Sheen)
+	assert.Equal(t, "albedo.png", library["textured"].DiffuseMap)
+	assert.Equal(t, "roughness.png", library["textured"].RoughnessMap)
+	assert.Equal(t, "normal.png", library["textured"].NormalMap)
+}
+
+func TestMTL_ShouldMapPBRToPrincipled(t *testing.T) {
+	library, err := importers.ReadMTL(strings.NewReader(pbrMaterialLibrary))
+	assert.NoError(t, err)
+
+	expected := materials.NewPrincipled(randomizer,
+		materials.BaseColor(textures.NewConstant(color.Red)),
+		materials.Roughness(textures.NewScalar(0.3)),
+		materials.Metallic(textures.NewScalar(1)),
+		materials.Sheen(textures.NewScalar(0.1)),
+		materials.Clearcoat(textures.NewScalar(0.5)),
+		materials.ClearcoatRoughness(textures.NewScalar(0.2)),
+		materials.Transmission(textures.NewScalar(0)))
+	assert.Equal(t, expected, library["paint"].ToMaterial(randomizer))
+}
+
+func TestMTL_ShouldLoadTextureMaps(t *testing.T) {
+	library, err := importers.ReadMTL(strings.NewReader(pbrMaterialLibrary))
+	assert.NoError(t, err)
+	loaded := map[string]bool{}
+	loader := func(filename string, linear bool) (textures.Texture, error) {
+		loaded[filename] = linear
+		return textures.NewConstant(color.Blue), nil
+	}
+
+	material, err := library["textured"].ToTexturedMaterial(randomizer, loader)
+
+	assert.NoError(t, err)
+	assert.IsType(t, materials.NormalMapped{}, material)
+	assert.Equal(t, map[string]bool{"albedo.png": false, "roughness.png": true, "normal.png": true}, loaded)
+}
+
+func TestMTL_ShouldReturnError_IfTextureMapFailsToLoad(t *testing.T) {
+	library, err := importers.ReadMTL(strings.NewReader(pbrMaterialLibrary))
+	assert.NoError(t, err)
+	loader := func(filename string, linear bool) (textures.Texture, error) {
+		return nil, fmt.Errorf("no such file")
+	}
+
+	_, err = library["textured"].ToTexturedMaterial(randomizer, loader)
+
+	assert.ErrorContains(t, err, "albedo.png")
+}
+
+func TestMTL_ShouldReturnError_IfTextureMapFileMissing(t *testing.T) {
+	_, err := importers.ReadMTL(strings.NewReader("newmtl bad\nmap_Kd"))
+
+	assert.ErrorContains(t, err, "line 2")
+}
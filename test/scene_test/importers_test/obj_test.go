@@ -0,0 +1,256 @@
+package importers_test
+
+import (
+	"bytes"
+	"image"
+	rgba "image/color"
+	"image/png"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/importers"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var randomizer = random.NewFakeRandomGenerator()
+
+const unitSquareOBJ = `
+# unit square in the XY plane
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+f 1 2 3 4
+`
+
+func TestOBJ_ShouldTriangulateQuadFace(t *testing.T) {
+	objects := loadOBJ(t, unitSquareOBJ)
+
+	assert.Len(t, objects, 1)
+	expectedBBox := core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 1, 0))
+	assert.Equal(t, expectedBBox, objects[0].BoundingBox())
+
+	ray := core.NewRay(core.NewVec3(0.25, 0.75, 1), core.NewVec3(0, 0, -1))
+	hit := objects[0].TestRay(ray, core.NewInterval(0, 10))
+	assert.EqualValues(t, 1, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(0, 0, 1), hit.Value().Normal)
+}
+
+func TestOBJ_ShouldUseDefaultMaterial_IfNoneSpecified(t *testing.T) {
+	objects := loadOBJ(t, unitSquareOBJ)
+
+	assert.Equal(t, materials.NewDiffusive(color.GrayLight, randomizer), objects[0].Material)
+}
+
+func TestOBJ_ShouldUseVertexNormals(t *testing.T) {
+	objects := loadOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 0 1 0
+vn 1 0 1
+vn 1 0 1
+vn 1 0 1
+f 1//1 2//2 3//3
+`)
+
+	ray := core.NewRay(core.NewVec3(0.25, 0.25, 1), core.NewVec3(0, 0, -1))
+	hit := objects[0].TestRay(ray, core.NewInterval(0, 10))
+	test.AssertInDeltaVec3(t, core.NewVec3(1, 0, 1).Normalize(), hit.Value().Normal, core.Tolerance)
+}
+
+func TestOBJ_ShouldUseFaceNormal_IfVertexNormalIsZero(t *testing.T) {
+	objects := loadOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 0 1 0
+vn 1 0 1
+vn 0 0 0
+vn 1 0 1
+f 1//1 2//2 3//3
+`)
+
+	ray := core.NewRay(core.NewVec3(0.25, 0.25, 1), core.NewVec3(0, 0, -1))
+	hit := objects[0].TestRay(ray, core.NewInterval(0, 10))
+	assert.Equal(t, core.NewVec3(0, 0, 1), hit.Value().Normal)
+}
+
+func TestOBJ_ShouldResolveNegativeIndices(t *testing.T) {
+	objects := loadOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 0 1 0
+vt 0 0
+vt 1 0
+vt 0 1
+f -3/-3 -2/-2 -1/-1
+`)
+
+	expectedBBox := core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 1, 0))
+	assert.Equal(t, expectedBBox, objects[0].BoundingBox())
+}
+
+func TestOBJ_ShouldInterpolateTextureCoordinates(t *testing.T) {
+	objects := loadOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 0 1 0
+vt 0.5 0.5
+vt 1 0.5
+vt 0.5 1 0
+f 1/1 2/2 3/3
+`)
+
+	ray := core.NewRay(core.NewVec3(0.5, 0.25, 1), core.NewVec3(0, 0, -1))
+	hit := objects[0].TestRay(ray, core.NewInterval(0, 10))
+	assert.InDelta(t, 0.75, hit.Value().UV.X(), core.Tolerance)
+	assert.InDelta(t, 0.625, hit.Value().UV.Y(), core.Tolerance)
+}
+
+func TestOBJ_ShouldReturnError_IfTextureCoordinatesMissing(t *testing.T) {
+	_, err := importers.LoadOBJ(objFS(`
+v 0 0 0
+v 1 0 0
+v 0 1 0
+f 1/1 2/1 3/1
+`), "model.obj", randomizer)
+
+	assert.ErrorContains(t, err, "texture coordinates")
+}
+
+func TestOBJ_ShouldSplitGroupsAndMaterialsIntoObjects(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"models/scene.obj": {Data: []byte(`
+mtllib scene.mtl
+v 0 0 0
+v 1 0 0
+v 0 1 0
+v 0 0 5
+v 1 0 5
+v 0 1 5
+g first
+usemtl red
+f 1 2 3
+g second
+usemtl light
+f 4 5 6
+usemtl red
+f 4 6 5
+`)},
+		"models/scene.mtl": {Data: []byte(`
+newmtl red
+Kd 1 0 0
+newmtl light
+Ke 1 1 1
+`)},
+	}
+
+	objects, err := importers.LoadOBJ(fileSystem, "models/scene.obj", randomizer)
+
+	assert.NoError(t, err)
+	assert.Len(t, objects, 3)
+	assert.Equal(t, materials.NewDiffusive(color.Red, randomizer), objects[0].Material)
+	assert.Equal(t, materials.NewDiffusiveLight(color.White, 1), objects[1].Material)
+	assert.Equal(t, materials.NewDiffusive(color.Red, randomizer), objects[2].Material)
+	assert.EqualValues(t, 5, objects[2].BoundingBox().Min().Z())
+}
+
+func TestOBJ_ShouldLoadTextureMapsRelativeToFile(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"models/model.obj": {Data: []byte(`
+mtllib model.mtl
+v 0 0 0
+v 1 0 0
+v 0 1 0
+vt 0 0
+vt 1 0
+vt 0 1
+usemtl painted
+f 1/1 2/2 3/3
+`)},
+		"models/model.mtl": {Data: []byte(`
+newmtl painted
+map_Kd textures/red.png
+`)},
+		"models/textures/red.png": {Data: redPNG(t)},
+	}
+
+	objects, err := importers.LoadOBJ(fileSystem, "models/model.obj", randomizer)
+
+	assert.NoError(t, err)
+	reflection := objects[0].Material.Reflect(core.NewVec3(0, 0, -1), core.SurfacePoint{Normal: core.NewVec3(0, 0, 1)})
+	assert.InDelta(t, 1, reflection.Color.R(), core.Tolerance)
+	assert.InDelta(t, 0, reflection.Color.G(), core.Tolerance)
+}
+
+func TestOBJ_ShouldReturnError_IfTextureMapMissing(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"model.obj": {Data: []byte("mtllib model.mtl\nv 0 0 0\nv 1 0 0\nv 0 1 0\nusemtl painted\nf 1 2 3\n")},
+		"model.mtl": {Data: []byte("newmtl painted\nmap_Kd missing.png\n")},
+	}
+
+	_, err := importers.LoadOBJ(fileSystem, "model.obj", randomizer)
+
+	assert.ErrorContains(t, err, "missing.png")
+}
+
+func redPNG(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, rgba.RGBA{R: 255, A: 255})
+	var buffer bytes.Buffer
+	assert.NoError(t, png.Encode(&buffer, img))
+	return buffer.Bytes()
+}
+
+func TestOBJ_ShouldSkipDegenerateFaces(t *testing.T) {
+	objects := loadOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 2 0 0
+f 1 2 3
+`)
+
+	assert.Empty(t, objects)
+}
+
+func TestOBJ_ShouldReturnError_IfIndexOutOfRange(t *testing.T) {
+	_, err := importers.LoadOBJ(objFS(`
+v 0 0 0
+f 1 2 3
+`), "model.obj", randomizer)
+
+	assert.ErrorContains(t, err, "line 3")
+}
+
+func TestOBJ_ShouldReturnError_IfMaterialUnknown(t *testing.T) {
+	_, err := importers.LoadOBJ(objFS(`
+v 0 0 0
+v 1 0 0
+v 0 1 0
+usemtl missing
+f 1 2 3
+`), "model.obj", randomizer)
+
+	assert.ErrorContains(t, err, "missing")
+}
+
+func TestOBJ_ShouldReturnError_IfFileMissing(t *testing.T) {
+	_, err := importers.LoadOBJ(fstest.MapFS{}, "model.obj", randomizer)
+
+	assert.Error(t, err)
+}
+
+func objFS(content string) fstest.MapFS {
+	return fstest.MapFS{"model.obj": {Data: []byte(content)}}
+}
+
+func loadOBJ(t *testing.T, content string) []scene.Object {
+	objects, err := importers.LoadOBJ(objFS(content), "model.obj", randomizer)
+	assert.NoError(t, err)
+	return objects
+}
@@ -0,0 +1,187 @@
+package importers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/importers"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const asciiQuadPLY = `ply
+format ascii 1.0
+comment a unit square with tilted vertex normals
+element vertex 4
+property float x
+property float y
+property float z
+property float nx
+property float ny
+property float nz
+element face 1
+property list uchar int vertex_indices
+end_header
+0 0 0 0 1 1
+1 0 0 0 1 1
+1 1 0 0 1 1
+0 1 0 0 1 1
+4 0 1 2 3
+`
+
+// Two slopes of a roof with the ridge along the Y axis, the left one is a quad, the right one a pentagon.
+const asciiRoofPLY = `ply
+format ascii 1.0
+element vertex 7
+property float x
+property float y
+property float z
+element material 1
+property uchar red
+property list uchar uchar name
+element face 2
+property uchar flags
+property list uchar uint vertex_indices
+end_header
+-1 0 0
+0 0 1
+1 0 0
+-1 1 0
+0 1 1
+1 1 0
+1 0.5 0
+255 3 1 2 3
+0 4 0 1 4 3
+0 5 1 2 6 5 4
+`
+
+func TestPLY_ShouldReadASCII_WithVertexNormals(t *testing.T) {
+	mesh, err := importers.ReadPLY(strings.NewReader(asciiQuadPLY))
+
+	assert.NoError(t, err)
+	// One ray for each triangle of the triangulated quad
+	for _, origin := range []core.Vec3{core.NewVec3(0.75, 0.25, 1), core.NewVec3(0.25, 0.75, 1)} {
+		hit := mesh.TestRay(core.NewRay(origin, core.NewVec3(0, 0, -1)), core.NewInterval(0, 10))
+		assert.EqualValues(t, 1, hit.Value().Param)
+		test.AssertInDeltaVec3(t, core.NewVec3(0, 1, 1).Normalize(), hit.Value().Normal, 1e-6)
+	}
+}
+
+func TestPLY_ShouldComputeSmoothNormals_IfFileHasNoVertexNormals(t *testing.T) {
+	mesh, err := importers.ReadPLY(strings.NewReader(asciiRoofPLY))
+
+	assert.NoError(t, err)
+	nearRidge := mesh.TestRay(core.NewRay(core.NewVec3(-0.01, 0.5, 5), core.NewVec3(0, 0, -1)), core.NewInterval(0, 10))
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 0, 1), nearRidge.Value().Normal, 0.05)
+	// The last fan triangle of the pentagon
+	pentagon := mesh.TestRay(core.NewRay(core.NewVec3(0.3, 0.8, 5), core.NewVec3(0, 0, -1)), core.NewInterval(0, 10))
+	assert.InDelta(t, 4.3, pentagon.Value().Param, 1e-5)
+}
+
+func TestPLY_ShouldComputeSmoothNormals_IfVertexNormalIsZero(t *testing.T) {
+	// The roof without the pentagon, the vertices on the ridge have zero normals
+	mesh, err := importers.ReadPLY(strings.NewReader(`ply
+format ascii 1.0
+element vertex 6
+property float x
+property float y
+property float z
+property float nx
+property float ny
+property float nz
+element face 2
+property list uchar int vertex_indices
+end_header
+-1 0 0 -1 0 1
+0 0 1 0 0 0
+1 0 0 1 0 1
+-1 1 0 -1 0 1
+0 1 1 0 0 0
+1 1 0 1 0 1
+4 0 1 4 3
+4 1 2 5 4
+`))
+
+	assert.NoError(t, err)
+	nearRidge := mesh.TestRay(core.NewRay(core.NewVec3(-0.01, 0.5, 5), core.NewVec3(0, 0, -1)), core.NewInterval(0, 10))
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 0, 1), nearRidge.Value().Normal, 0.05)
+}
+
+func TestPLY_ShouldReadBinary(t *testing.T) {
+	byteOrders := map[string]binary.ByteOrder{
+		"binary_little_endian": binary.LittleEndian,
+		"binary_big_endian":    binary.BigEndian,
+	}
+
+	for format, byteOrder := range byteOrders {
+		t.Run(format, func(t *testing.T) {
+			data := binaryPLY(format, byteOrder,
+				[][3]float32{{0, 0, 0}, {2, 0, 0}, {2, 1, 0}, {0, 1, 0}},
+				[][]int32{{0, 1, 2, 3}})
+
+			mesh, err := importers.ReadPLY(data)
+
+			assert.NoError(t, err)
+			expectedBBox := core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(2, 1, 0))
+			assert.Equal(t, expectedBBox, mesh.BoundingBox())
+			hit := mesh.TestRay(core.NewRay(core.NewVec3(0.5, 0.75, 1), core.NewVec3(0, 0, -1)), core.NewInterval(0, 10))
+			assert.EqualValues(t, 1, hit.Value().Param)
+		})
+	}
+}
+
+func TestPLY_ShouldReturnError_IfMalformed(t *testing.T) {
+	triangle := binaryPLY("binary_little_endian", binary.LittleEndian,
+		[][3]float32{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+		[][]int32{{0, 1, 2}}).Bytes()
+	malformedFiles := map[string][]byte{
+		"empty":              {},
+		"not ply":            []byte(strings.Replace(asciiQuadPLY, "ply", "obj", 1)),
+		"unknown format":     []byte(strings.Replace(asciiQuadPLY, "format ascii", "format binary_middle_endian", 1)),
+		"bad property type":  []byte(strings.Replace(asciiQuadPLY, "property float y", "property float24 y", 1)),
+		"bad list type":      []byte(strings.Replace(asciiQuadPLY, "list uchar int", "list float int", 1)),
+		"truncated header":   []byte(asciiQuadPLY[:strings.Index(asciiQuadPLY, "end_header")]),
+		"truncated ascii":    []byte(strings.Replace(asciiQuadPLY, "4 0 1 2 3", "4 0 1 2", 1)),
+		"truncated binary":   triangle[:len(triangle)-3],
+		"index out of range": []byte(strings.Replace(asciiQuadPLY, "4 0 1 2 3", "4 0 1 2 4", 1)),
+		"negative index":     []byte(strings.Replace(asciiQuadPLY, "4 0 1 2 3", "4 0 1 2 -1", 1)),
+		"too few indices":    []byte(strings.Replace(asciiQuadPLY, "4 0 1 2 3", "2 0 1", 1)),
+		"no faces":           []byte(strings.Replace(asciiQuadPLY, "element face 1", "element face 0", 1)),
+	}
+
+	for name, content := range malformedFiles {
+		t.Run(name, func(t *testing.T) {
+			_, err := importers.ReadPLY(bytes.NewReader(content))
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPLY_ShouldReturnError_IfFileDoesNotExist(t *testing.T) {
+	_, err := importers.LoadPLYFile("does-not-exist.ply")
+
+	assert.Error(t, err)
+}
+
+// Vertices have float coordinates, faces are lists of int indices with a uchar count.
+func binaryPLY(format string, byteOrder binary.ByteOrder, vertices [][3]float32, faces [][]int32) *bytes.Buffer {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString("ply\nformat " + format + " 1.0\n")
+	buffer.WriteString("element vertex " + strconv.Itoa(len(vertices)) + "\n")
+	buffer.WriteString("property float x\nproperty float y\nproperty float z\n")
+	buffer.WriteString("element face " + strconv.Itoa(len(faces)) + "\n")
+	buffer.WriteString("property list uchar int vertex_indices\nend_header\n")
+	for _, vertex := range vertices {
+		binary.Write(buffer, byteOrder, vertex)
+	}
+	for _, face := range faces {
+		buffer.WriteByte(byte(len(face)))
+		binary.Write(buffer, byteOrder, face)
+	}
+	return buffer
+}
@@ -0,0 +1,95 @@
+package importers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/importers"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const asciiTriangleSTL = `solid triangle
+  facet normal 0 0 1
+    outer loop
+      vertex 0 0 0
+      vertex 1 0 0
+      vertex 0 1 0
+    endloop
+  endfacet
+endsolid triangle
+`
+
+func TestSTL_ShouldReadASCII(t *testing.T) {
+	mesh, err := importers.ReadSTL(strings.NewReader(asciiTriangleSTL))
+
+	assert.NoError(t, err)
+	ray := core.NewRay(core.NewVec3(0.25, 0.25, 1), core.NewVec3(0, 0, -1))
+	hit := mesh.TestRay(ray, core.NewInterval(0, 10))
+	assert.EqualValues(t, 1, hit.Value().Param)
+	assert.Equal(t, core.NewVec3(0, 0, 1), hit.Value().Normal)
+}
+
+func TestSTL_ShouldReadBinary_EvenIfHeaderStartsWithSolid(t *testing.T) {
+	data := binarySTL("solid but binary", [][12]float32{
+		{0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1, 0},
+	})
+
+	mesh, err := importers.ReadSTL(data)
+
+	assert.NoError(t, err)
+	expectedBBox := core.NewBox(core.NewVec3(0, 0, 0), core.NewVec3(1, 1, 0))
+	assert.Equal(t, expectedBBox, mesh.BoundingBox())
+}
+
+func TestSTL_ShouldComputeSmoothNormals_IfFacetNormalsAreZero(t *testing.T) {
+	// Two triangles forming a roof with the ridge along the Y axis
+	data := binarySTL("roof", [][12]float32{
+		{0, 0, 0, -1, 0, 0, 0, 0, 1, 0, 1, 1},
+		{0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 1, 1},
+	})
+
+	mesh, err := importers.ReadSTL(data)
+
+	assert.NoError(t, err)
+	ray := core.NewRay(core.NewVec3(-0.01, 0.5, 5), core.NewVec3(0, 0, -1))
+	hit := mesh.TestRay(ray, core.NewInterval(0, 10))
+	test.AssertInDeltaVec3(t, core.NewVec3(0, 0, 1), hit.Value().Normal, 0.05)
+}
+
+func TestSTL_ShouldReturnError_IfMalformed(t *testing.T) {
+	truncatedBinary := binarySTL("binary", [][12]float32{{}, {}}).Bytes()
+	malformedFiles := map[string][]byte{
+		"empty":           {},
+		"truncated":       truncatedBinary[:len(truncatedBinary)-10],
+		"too few vertex":  []byte(strings.Replace(asciiTriangleSTL, "vertex 0 1 0", "", 1)),
+		"bad number":      []byte(strings.Replace(asciiTriangleSTL, "vertex 1 0 0", "vertex 1 zero 0", 1)),
+		"unknown keyword": []byte(strings.Replace(asciiTriangleSTL, "outer loop", "inner loop", 1)),
+		"only degenerate": []byte(strings.Replace(asciiTriangleSTL, "vertex 0 1 0", "vertex 2 0 0", 1)),
+	}
+
+	for name, content := range malformedFiles {
+		t.Run(name, func(t *testing.T) {
+			_, err := importers.ReadSTL(bytes.NewReader(content))
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+// Each facet is a normal followed by three vertices.
+func binarySTL(header string, facets [][12]float32) *bytes.Buffer {
+	buffer := &bytes.Buffer{}
+	headerBytes := [80]byte{}
+	copy(headerBytes[:], header)
+	buffer.Write(headerBytes[:])
+	binary.Write(buffer, binary.LittleEndian, uint32(len(facets)))
+	for _, facet := range facets {
+		binary.Write(buffer, binary.LittleEndian, facet)
+		binary.Write(buffer, binary.LittleEndian, uint16(0))
+	}
+	return buffer
+}
@@ -0,0 +1,52 @@
+package materials_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+)
+
+// Height grows linearly with U.
+type rampTexture struct{}
+
+func (rampTexture) ColorAt(surface core.SurfacePoint) color.Color {
+	height := surface.UV.X()
+	return color.New(height, height, height)
+}
+
+// Height grows linearly with X.
+type solidRampTexture struct{}
+
+func (solidRampTexture) ColorAt(surface core.SurfacePoint) color.Color {
+	height := surface.Point.X()
+	return color.New(height, height, height)
+}
+
+func TestBumpMapped_ShouldKeepNormal_ForConstantHeight(t *testing.T) {
+	material := materials.NewBumpMapped(
+		materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator()),
+		textures.NewConstant(color.GrayMedium), 1)
+
+	test.AssertInDeltaVec3(t, NORMAL_AT_HIT_POINT, shadingNormal(material, TANGENT_SURFACE), core.Tolerance)
+}
+
+func TestBumpMapped_ShouldTiltNormalAgainstUVGradient(t *testing.T) {
+	material := materials.NewBumpMapped(
+		materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator()), rampTexture{}, 1)
+
+	expected := core.NewVec3(-1, 1, 0).Normalize()
+	test.AssertInDeltaVec3(t, expected, shadingNormal(material, TANGENT_SURFACE), 1e-3)
+}
+
+func TestBumpMapped_ShouldTiltNormalAgainstSolidGradient(t *testing.T) {
+	material := materials.NewBumpMapped(
+		materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator()), solidRampTexture{}, 2)
+
+	expected := core.NewVec3(-2, 1, 0).Normalize()
+	test.AssertInDeltaVec3(t, expected, shadingNormal(material, TANGENT_SURFACE), 1e-3)
+}
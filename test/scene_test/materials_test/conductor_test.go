@@ -0,0 +1,115 @@
+package materials_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConductor_ShouldMatchFresnelAtNormalIncidence(t *testing.T) {
+	material := materials.NewConductor(materials.GoldIOR, 0, random.NewRandomGenerator())
+
+	reflection := material.Reflect(NORMAL_AT_HIT_POINT.Mul(-1), SURFACE)
+
+	assert.Equal(t, materials.Scattered, reflection.Type)
+	eta, k := materials.GoldIOR.Eta.R(), materials.GoldIOR.K.R()
+	expected := ((eta-1)*(eta-1) + k*k) / ((eta+1)*(eta+1) + k*k)
+	assert.InDelta(t, expected, reflection.Color.R(), 1e-5)
+}
+
+func TestConductor_PresetsShouldHaveMetallicColors(t *testing.T) {
+	gold := normalIncidenceReflectance(materials.GoldIOR)
+	copper := normalIncidenceReflectance(materials.CopperIOR)
+	aluminium := normalIncidenceReflectance(materials.AluminiumIOR)
+	silver := normalIncidenceReflectance(materials.SilverIOR)
+	iron := normalIncidenceReflectance(materials.IronIOR)
+
+	assert.Greater(t, gold.R(), gold.B())
+	assert.Greater(t, copper.R(), copper.G())
+	assert.Greater(t, aluminium.B(), core.Real(0.9))
+	assert.Greater(t, silver.G(), core.Real(0.9))
+	assert.Less(t, iron.G(), core.Real(0.6))
+}
+
+func normalIncidenceReflectance(ior materials.ComplexIOR) color.Color {
+	material := materials.NewConductor(ior, 0, random.NewRandomGenerator())
+	return material.Reflect(NORMAL_AT_HIT_POINT.Mul(-1), SURFACE).Color
+}
+
+func TestConductor_ShouldMirror_WhenSmooth(t *testing.T) {
+	material := materials.NewConductor(materials.SilverIOR, 0, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(4, -3, 0)
+
+	reflection := material.Reflect(incidentDirection, SURFACE)
+
+	test.AssertInDeltaVec3(t, core.NewVec3(4, 3, 0), reflection.Ray.Direction(), core.Tolerance)
+	assert.Equal(t, color.Black, material.BRDF(incidentDirection, core.NewVec3(4, 3, 0), SURFACE))
+	assert.EqualValues(t, 0, material.PDF(incidentDirection, core.NewVec3(4, 3, 0), SURFACE))
+}
+
+func TestConductor_ShouldAbsorb_IfHitFromBelow(t *testing.T) {
+	material := materials.NewConductor(materials.SilverIOR, 0.5, random.NewRandomGenerator())
+
+	reflection := material.Reflect(core.NewVec3(1, 1, 0), SURFACE)
+
+	assert.Equal(t, materials.Absorbed, reflection.Type)
+}
+
+func TestConductor_PDFShouldIntegrateToAlmostOne(t *testing.T) {
+	material := materials.NewConductor(materials.GoldIOR, 0.5, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(1, -3, 0)
+
+	integral := integrateOverSphere(func(direction core.Vec3) core.Real {
+		return material.PDF(incidentDirection, direction, SURFACE)
+	})
+
+	// Some of the sampled directions go below the surface and are absorbed
+	assert.InDelta(t, 0.97, integral, 0.03)
+}
+
+func TestConductor_SampleWeightShouldMatchBRDFAndPDF(t *testing.T) {
+	material := materials.NewConductor(materials.CopperIOR, 0.6, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(2, -1, 1)
+
+	for i := 0; i < 100; i++ {
+		reflection := material.Reflect(incidentDirection, SURFACE)
+		if reflection.Type == materials.Absorbed {
+			continue
+		}
+		direction := reflection.Ray.Direction()
+		brdf := material.BRDF(incidentDirection, direction, SURFACE)
+		pdf := material.PDF(incidentDirection, direction, SURFACE)
+		cosine := direction.Normalize().Dot(NORMAL_AT_HIT_POINT)
+		assert.InDelta(t, reflection.Color.R(), brdf.R()*cosine/pdf, 1e-3)
+		assert.InDelta(t, reflection.Color.B(), brdf.B()*cosine/pdf, 1e-3)
+	}
+}
+
+func TestConductor_SamplingShouldEstimateAlbedo(t *testing.T) {
+	material := materials.NewConductor(materials.AluminiumIOR, 0.7, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(1, -1, 0)
+
+	albedo := integrateOverSphere(func(direction core.Vec3) core.Real {
+		cosine := core.Max(0, direction.Dot(NORMAL_AT_HIT_POINT))
+		return material.BRDF(incidentDirection, direction, SURFACE).G() * cosine
+	})
+
+	const samples = 20000
+	estimate := core.Real(0)
+	for i := 0; i < samples; i++ {
+		estimate += material.Reflect(incidentDirection, SURFACE).Color.G() / samples
+	}
+
+	assert.InDelta(t, albedo, estimate, 0.02)
+	assert.Less(t, albedo, core.Real(1))
+}
+
+func TestConductor_RoughnessShouldBeInUnitRange(t *testing.T) {
+	assert.Panics(t, func() { materials.NewConductor(materials.GoldIOR, -0.1, random.NewRandomGenerator()) })
+	assert.Panics(t, func() { materials.NewConductor(materials.GoldIOR, 1.1, random.NewRandomGenerator()) })
+}
@@ -3,6 +3,8 @@ package materials_test
 import (
 	"testing"
 
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,8 +14,27 @@ const LIGHT_INTENSITY = 2
 func TestDiffusiveLight_ShouldEmitLight(t *testing.T) {
 	material := materials.NewDiffusiveLight(MATERIAL_COLOR, LIGHT_INTENSITY)
 
-	reflection := material.Reflect(RAY_DIRECTION, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(RAY_DIRECTION, SURFACE)
 
 	assert.Equal(t, materials.Emitted, reflection.Type)
 	assert.Equal(t, MATERIAL_COLOR.Mul(LIGHT_INTENSITY), reflection.Color)
 }
+
+func TestDiffusiveLight_ShouldBeEmitter(t *testing.T) {
+	var material materials.Material = materials.NewDiffusiveLight(MATERIAL_COLOR, LIGHT_INTENSITY)
+
+	emitter, isEmitter := material.(materials.Emitter)
+
+	assert.True(t, isEmitter)
+	assert.Equal(t, MATERIAL_COLOR.Mul(LIGHT_INTENSITY), emitter.Emission(SURFACE))
+	assert.Equal(t, color.Black, material.BRDF(RAY_DIRECTION, NORMAL_AT_HIT_POINT, SURFACE))
+}
+
+func TestDiffusiveLight_ShouldTakeEmissionFromTexture(t *testing.T) {
+	material := materials.NewDiffusiveLightTextured(checkerTexture(), LIGHT_INTENSITY)
+	whiteSquare := core.SurfacePoint{Point: HIT_POINT, Normal: NORMAL_AT_HIT_POINT, UV: core.NewVec2(0.5, 0.5)}
+	blackSquare := core.SurfacePoint{Point: HIT_POINT, Normal: NORMAL_AT_HIT_POINT, UV: core.NewVec2(1.5, 0.5)}
+
+	assert.Equal(t, color.White.Mul(LIGHT_INTENSITY), material.Emission(whiteSquare))
+	assert.Equal(t, color.Black, material.Emission(blackSquare))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,11 +15,12 @@ var RAY_DIRECTION = core.NewVec3(10, 20, 30)
 var MATERIAL_COLOR = color.Red
 var HIT_POINT = core.NewVec3(0, 1, 2)
 var NORMAL_AT_HIT_POINT = core.NewVec3(0, 1, 0)
+var SURFACE = core.SurfacePoint{Point: HIT_POINT, Normal: NORMAL_AT_HIT_POINT}
 
 func TestDiffusive_ShouldReflectRayInNormalDirection_WhenNotRandom(t *testing.T) {
 	material := materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator())
 
-	reflection := material.Reflect(RAY_DIRECTION, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(RAY_DIRECTION, SURFACE)
 
 	expected := materials.Reflection{
 		Type:  materials.Scattered,
@@ -28,13 +30,54 @@ func TestDiffusive_ShouldReflectRayInNormalDirection_WhenNotRandom(t *testing.T)
 	assert.Equal(t, expected, reflection)
 }
 
-func TestDiffusive_ShouldReflectRayWithinUnitSphereOfNormal_WhenRandom(t *testing.T) {
+func TestDiffusive_ShouldReflectRayOnUnitSphereAroundNormal_WhenRandom(t *testing.T) {
 	material := materials.NewDiffusive(MATERIAL_COLOR, random.NewRandomGenerator())
 
-	reflection := material.Reflect(RAY_DIRECTION, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(RAY_DIRECTION, SURFACE)
 
 	randomPerturbation := reflection.Ray.Direction().Sub(NORMAL_AT_HIT_POINT).Len()
-	assert.Less(t, randomPerturbation, core.Real(1))
+	assert.InDelta(t, 1, randomPerturbation, core.Tolerance)
 	assert.Equal(t, MATERIAL_COLOR, reflection.Color)
 	assert.Equal(t, HIT_POINT, reflection.Ray.Origin())
 }
+
+func TestDiffusive_BRDFShouldBeLambertian_AboveSurface(t *testing.T) {
+	material := materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator())
+
+	brdf := material.BRDF(RAY_DIRECTION, core.NewVec3(1, 1, 0), SURFACE)
+
+	assert.Equal(t, MATERIAL_COLOR.Div(core.Pi), brdf)
+}
+
+func TestDiffusive_BRDFShouldBeBlack_BelowSurface(t *testing.T) {
+	material := materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator())
+
+	brdf := material.BRDF(RAY_DIRECTION, core.NewVec3(1, -1, 0), SURFACE)
+
+	assert.Equal(t, color.Black, brdf)
+}
+
+func TestDiffusive_PDFShouldIntegrateToOne(t *testing.T) {
+	material := materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator())
+
+	integral := integrateOverSphere(func(direction core.Vec3) core.Real {
+		return material.PDF(RAY_DIRECTION, direction, SURFACE)
+	})
+
+	assert.InDelta(t, 1, integral, 1e-3)
+	assert.EqualValues(t, 1/core.Pi, material.PDF(RAY_DIRECTION, NORMAL_AT_HIT_POINT, SURFACE))
+}
+
+func TestDiffusive_ShouldTakeColorFromTexture(t *testing.T) {
+	material := materials.NewDiffusiveTextured(checkerTexture(), random.NewFakeRandomGenerator())
+	whiteSquare := core.SurfacePoint{Point: HIT_POINT, Normal: NORMAL_AT_HIT_POINT, UV: core.NewVec2(0.5, 0.5)}
+	blackSquare := core.SurfacePoint{Point: HIT_POINT, Normal: NORMAL_AT_HIT_POINT, UV: core.NewVec2(1.5, 0.5)}
+
+	assert.Equal(t, color.White, material.Reflect(RAY_DIRECTION, whiteSquare).Color)
+	assert.Equal(t, color.Black, material.Reflect(RAY_DIRECTION, blackSquare).Color)
+	assert.Equal(t, color.White.Div(core.Pi), material.BRDF(RAY_DIRECTION, NORMAL_AT_HIT_POINT, whiteSquare))
+}
+
+func checkerTexture() textures.Texture {
+	return textures.NewCheckerboard(textures.NewConstant(color.White), textures.NewConstant(color.Black), 1)
+}
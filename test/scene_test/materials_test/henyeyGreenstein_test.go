@@ -0,0 +1,60 @@
+package materials_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHenyeyGreenstein_PhaseShouldIntegrateToOne(t *testing.T) {
+	for _, asymmetry := range []core.Real{-0.5, 0, 0.7} {
+		material := materials.NewHenyeyGreenstein(MATERIAL_COLOR, asymmetry, random.NewRandomGenerator())
+
+		integral := integrateOverSphere(func(direction core.Vec3) core.Real {
+			return material.PDF(INCIDENT_DIRECTION, direction, SURFACE)
+		})
+
+		assert.InDelta(t, 1, integral, 0.01)
+	}
+}
+
+func TestHenyeyGreenstein_ShouldBeUniform_WhenIsotropic(t *testing.T) {
+	material := materials.NewIsotropic(MATERIAL_COLOR, random.NewRandomGenerator())
+
+	assert.InDelta(t, 1/(4*core.Pi), material.Phase(INCIDENT_DIRECTION, REFLECTED_DIRECTION), core.Tolerance)
+	assert.Equal(t, MATERIAL_COLOR.Mul(1/(4*core.Pi)), material.BRDF(INCIDENT_DIRECTION, REFLECTED_DIRECTION, SURFACE))
+}
+
+func TestHenyeyGreenstein_SampledCosineShouldAverageToAsymmetry(t *testing.T) {
+	const asymmetry = 0.6
+	material := materials.NewHenyeyGreenstein(MATERIAL_COLOR, asymmetry, random.NewRandomGenerator())
+	forward := INCIDENT_DIRECTION.Normalize()
+
+	const samples = 20000
+	meanCosine := core.Real(0)
+	for i := 0; i < samples; i++ {
+		reflection := material.Reflect(INCIDENT_DIRECTION, SURFACE)
+		assert.Equal(t, MATERIAL_COLOR, reflection.Color)
+		meanCosine += reflection.Ray.Direction().Normalize().Dot(forward) / samples
+	}
+
+	assert.InDelta(t, asymmetry, meanCosine, 0.02)
+}
+
+func TestHenyeyGreenstein_ShouldScatterForward_WhenRandomReturnsOne(t *testing.T) {
+	material := materials.NewHenyeyGreenstein(MATERIAL_COLOR, 0.5, random.FakeRandomGenerator{RealValue: 1})
+
+	reflection := material.Reflect(INCIDENT_DIRECTION, SURFACE)
+
+	assert.Equal(t, materials.Scattered, reflection.Type)
+	assert.Equal(t, HIT_POINT, reflection.Ray.Origin())
+	assert.True(t, reflection.Ray.Direction().InDelta(INCIDENT_DIRECTION.Normalize(), 1e-3))
+}
+
+func TestHenyeyGreenstein_AsymmetryShouldBeInOpenUnitRange(t *testing.T) {
+	assert.Panics(t, func() { materials.NewHenyeyGreenstein(MATERIAL_COLOR, 1, random.NewRandomGenerator()) })
+	assert.Panics(t, func() { materials.NewHenyeyGreenstein(MATERIAL_COLOR, -1, random.NewRandomGenerator()) })
+}
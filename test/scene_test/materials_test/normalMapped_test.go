@@ -0,0 +1,61 @@
+package materials_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// The surface at HIT_POINT with the tangent along X and the bitangent along -Z.
+var TANGENT_SURFACE = core.SurfacePoint{
+	Point:     HIT_POINT,
+	Normal:    NORMAL_AT_HIT_POINT,
+	Tangent:   core.NewVec3(1, 0, 0),
+	Bitangent: core.NewVec3(0, 0, -1),
+}
+
+// Diffusive reflection with a fake randomizer scatters along the shading normal.
+func shadingNormal(material materials.Material, surface core.SurfacePoint) core.Vec3 {
+	return material.Reflect(RAY_DIRECTION, surface).Ray.Direction().Normalize()
+}
+
+func TestNormalMapped_ShouldKeepNormal_ForFlatMap(t *testing.T) {
+	flat := textures.NewConstant(color.New(0.5, 0.5, 1))
+	material := materials.NewNormalMapped(materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator()), flat)
+
+	test.AssertInDeltaVec3(t, NORMAL_AT_HIT_POINT, shadingNormal(material, TANGENT_SURFACE), core.Tolerance)
+}
+
+func TestNormalMapped_ShouldTiltNormalInTangentSpace(t *testing.T) {
+	tiltedTowardsTangent := textures.NewConstant(color.New(1, 0.5, 0.5))
+	material := materials.NewNormalMapped(materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator()), tiltedTowardsTangent)
+
+	test.AssertInDeltaVec3(t, core.NewVec3(1, 0, 0), shadingNormal(material, TANGENT_SURFACE), core.Tolerance)
+}
+
+func TestNormalMapped_ShouldUsePerturbedNormalForBRDF(t *testing.T) {
+	tiltedTowardsBitangent := textures.NewConstant(color.New(0.5, 1, 0.5))
+	material := materials.NewNormalMapped(materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator()), tiltedTowardsBitangent)
+
+	// Above the geometric surface, but below the shading one
+	scatteredDirection := core.NewVec3(0, 1, 2)
+
+	assert.Equal(t, color.Black, material.BRDF(RAY_DIRECTION, scatteredDirection, TANGENT_SURFACE))
+	assert.EqualValues(t, 0, material.PDF(RAY_DIRECTION, scatteredDirection, TANGENT_SURFACE))
+}
+
+func TestNormalMapped_ShouldWork_WithoutTangentFrame(t *testing.T) {
+	tilted := textures.NewConstant(color.New(1, 0.5, 0.75))
+	material := materials.NewNormalMapped(materials.NewDiffusive(MATERIAL_COLOR, random.NewFakeRandomGenerator()), tilted)
+
+	normal := shadingNormal(material, SURFACE)
+
+	assert.InDelta(t, 1, normal.Len(), core.Tolerance)
+	assert.InDelta(t, 0.5/core.Sqrt(1.25), normal.Dot(NORMAL_AT_HIT_POINT), core.Tolerance)
+}
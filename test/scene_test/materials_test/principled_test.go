@@ -0,0 +1,155 @@
+package materials_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/stretchr/testify/assert"
+)
+
+func layeredPrincipled() materials.Principled {
+	return materials.NewPrincipled(random.NewRandomGenerator(),
+		materials.BaseColor(textures.NewConstant(color.New(0.8, 0.4, 0.2))),
+		materials.Metallic(textures.NewScalar(0.3)),
+		materials.Roughness(textures.NewScalar(0.4)),
+		materials.SpecularTint(textures.NewScalar(0.5)),
+		materials.Sheen(textures.NewScalar(0.5)),
+		materials.Clearcoat(textures.NewScalar(1)),
+	)
+}
+
+func TestPrincipled_PDFShouldIntegrateToAlmostOne(t *testing.T) {
+	material := layeredPrincipled()
+	incidentDirection := core.NewVec3(1, -2, 0)
+
+	integral := integrateOverSphere(func(direction core.Vec3) core.Real {
+		return material.PDF(incidentDirection, direction, SURFACE)
+	})
+
+	// Some of the specular samples go below the surface and are absorbed
+	assert.InDelta(t, 0.98, integral, 0.02)
+}
+
+func TestPrincipled_PDFShouldIntegrateToAlmostOne_WithTransmission(t *testing.T) {
+	material := materials.NewPrincipled(random.NewRandomGenerator(),
+		materials.Roughness(textures.NewScalar(0.5)),
+		materials.Transmission(textures.NewScalar(0.7)))
+	incidentDirection := core.NewVec3(1, -2, 0)
+
+	integral := integrateOverSphere(func(direction core.Vec3) core.Real {
+		return material.PDF(incidentDirection, direction, SURFACE)
+	})
+	transmitted := integrateOverSphere(func(direction core.Vec3) core.Real {
+		return core.IfElse(direction.Dot(NORMAL_AT_HIT_POINT) < 0, material.PDF(incidentDirection, direction, SURFACE), 0)
+	})
+
+	assert.InDelta(t, 0.97, integral, 0.03)
+	assert.Greater(t, transmitted, core.Real(0.3))
+}
+
+func TestPrincipled_SampleWeightShouldMatchBRDFAndPDF(t *testing.T) {
+	material := materials.NewPrincipled(random.NewRandomGenerator(),
+		materials.Sheen(textures.NewScalar(1)),
+		materials.Clearcoat(textures.NewScalar(0.5)),
+		materials.Transmission(textures.NewScalar(0.5)))
+	incidentDirection := core.NewVec3(2, -1, 1)
+
+	for i := 0; i < 200; i++ {
+		reflection := material.Reflect(incidentDirection, SURFACE)
+		if reflection.Type == materials.Absorbed {
+			continue
+		}
+		direction := reflection.Ray.Direction()
+		brdf := material.BRDF(incidentDirection, direction, SURFACE)
+		pdf := material.PDF(incidentDirection, direction, SURFACE)
+		cosine := core.Abs(direction.Normalize().Dot(NORMAL_AT_HIT_POINT))
+		assert.InDelta(t, reflection.Color.R(), brdf.R()*cosine/pdf, 1e-3)
+		assert.InDelta(t, reflection.Color.G(), brdf.G()*cosine/pdf, 1e-3)
+	}
+}
+
+func TestPrincipled_SamplingShouldEstimateAlbedo(t *testing.T) {
+	material := layeredPrincipled()
+	incidentDirection := core.NewVec3(1, -1, 0)
+
+	albedo := integrateOverSphere(func(direction core.Vec3) core.Real {
+		cosine := core.Max(0, direction.Dot(NORMAL_AT_HIT_POINT))
+		return material.BRDF(incidentDirection, direction, SURFACE).R() * cosine
+	})
+
+	const samples = 20000
+	estimate := core.Real(0)
+	for i := 0; i < samples; i++ {
+		reflection := material.Reflect(incidentDirection, SURFACE)
+		if reflection.Type == materials.Scattered {
+			estimate += reflection.Color.R() / samples
+		}
+	}
+
+	assert.InDelta(t, albedo, estimate, 0.02)
+	assert.Less(t, albedo, core.Real(1))
+}
+
+func TestPrincipled_ShouldReflectBaseColor_WhenMetallic(t *testing.T) {
+	material := materials.NewPrincipled(random.NewRandomGenerator(),
+		materials.BaseColor(textures.NewConstant(color.Golden)),
+		materials.Metallic(textures.NewScalar(1)),
+		materials.Roughness(textures.NewScalar(0.2)))
+	incidentDirection := core.NewVec3(1, -2, 0)
+	mirrored := core.NewVec3(1, 2, 0)
+
+	brdf := material.BRDF(incidentDirection, mirrored, SURFACE)
+	offSpecular := material.BRDF(incidentDirection, core.NewVec3(-1, 2, 0), SURFACE)
+
+	assert.Greater(t, brdf.R(), brdf.B())
+	assert.Greater(t, brdf.R(), 100*offSpecular.R())
+}
+
+func TestPrincipled_ShouldLookTheSameFromBothSides_WithoutTransmission(t *testing.T) {
+	material := layeredPrincipled()
+	incidentDirection := core.NewVec3(1, -2, 1)
+	scatteredDirection := core.NewVec3(-1, 3, 2)
+	flipped := func(v core.Vec3) core.Vec3 { return core.NewVec3(v.X(), -v.Y(), v.Z()) }
+
+	above := material.BRDF(incidentDirection, scatteredDirection, SURFACE)
+	below := material.BRDF(flipped(incidentDirection), flipped(scatteredDirection), SURFACE)
+
+	assert.InDelta(t, above.R(), below.R(), 1e-4)
+	assert.InDelta(t, material.PDF(incidentDirection, scatteredDirection, SURFACE),
+		material.PDF(flipped(incidentDirection), flipped(scatteredDirection), SURFACE), 1e-4)
+}
+
+func TestPrincipled_ShouldTakeParametersFromTextures(t *testing.T) {
+	material := materials.NewPrincipled(random.NewRandomGenerator(),
+		materials.BaseColor(textures.NewConstant(color.Red)),
+		materials.Metallic(checkerTexture()))
+	incidentDirection := core.NewVec3(1, -2, 0)
+	scatteredDirection := core.NewVec3(1, 2, 0)
+	metalSquare := core.SurfacePoint{Point: HIT_POINT, Normal: NORMAL_AT_HIT_POINT, UV: core.NewVec2(0.5, 0.5)}
+	dielectricSquare := core.SurfacePoint{Point: HIT_POINT, Normal: NORMAL_AT_HIT_POINT, UV: core.NewVec2(1.5, 0.5)}
+
+	metal := material.BRDF(incidentDirection, scatteredDirection, metalSquare)
+	dielectric := material.BRDF(incidentDirection, scatteredDirection, dielectricSquare)
+
+	assert.InDelta(t, 0, metal.G(), 1e-4)
+	assert.Greater(t, dielectric.G(), core.Real(0))
+}
+
+func TestPrincipled_ShouldAbsorb_WhenBlack(t *testing.T) {
+	material := materials.NewPrincipled(random.NewRandomGenerator(),
+		materials.BaseColor(textures.NewConstant(color.Black)),
+		materials.Specular(textures.NewScalar(0)),
+		materials.Metallic(textures.NewScalar(1)))
+
+	reflection := material.Reflect(core.NewVec3(0, -1, 0), SURFACE)
+
+	assert.Equal(t, materials.Absorbed, reflection.Type)
+}
+
+func TestPrincipled_IORCantBeLessThanOne(t *testing.T) {
+	assert.Panics(t, func() { materials.IOR(0.9) })
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
 	"github.com/stretchr/testify/assert"
@@ -13,7 +14,7 @@ func TestReflective_ShouldReflectRayAroundNormal_WhenNotFuzzy(t *testing.T) {
 	material := materials.NewReflective(MATERIAL_COLOR, random.NewRandomGenerator())
 	incidentDirection := core.NewVec3(4, -3, 0)
 
-	reflection := material.Reflect(incidentDirection, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(incidentDirection, SURFACE)
 
 	expected := materials.Reflection{
 		Type:  materials.Scattered,
@@ -27,7 +28,7 @@ func TestReflective_ShouldNotReflect_WhenRayParallelToSurface(t *testing.T) {
 	material := materials.NewReflective(MATERIAL_COLOR, random.NewRandomGenerator())
 	incidentDirection := core.NewVec3(4, 0, 0)
 
-	reflection := material.Reflect(incidentDirection, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(incidentDirection, SURFACE)
 
 	assert.Equal(t, materials.Absorbed, reflection.Type)
 }
@@ -36,7 +37,7 @@ func TestReflective_ShouldNotReflect_WhenRaysNormalComponentCoalignedWithNormal(
 	material := materials.NewReflective(MATERIAL_COLOR, random.NewRandomGenerator())
 	incidentDirection := core.NewVec3(4, 3, 0)
 
-	reflection := material.Reflect(incidentDirection, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(incidentDirection, SURFACE)
 
 	assert.Equal(t, materials.Absorbed, reflection.Type)
 }
@@ -46,7 +47,7 @@ func TestRefective_WithFuzziness(t *testing.T) {
 	material := materials.NewReflectiveFuzzy(MATERIAL_COLOR, fuzziness, random.NewRandomGenerator())
 	incidentDirection := core.NewVec3(4, -3, 0)
 
-	reflection := material.Reflect(incidentDirection, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(incidentDirection, SURFACE)
 
 	expectedMeanDirection := core.NewVec3(4, 3, 0).Normalize()
 	randomPerturbation := reflection.Ray.Direction().Sub(expectedMeanDirection).Len()
@@ -64,3 +65,75 @@ func TestReflective_FuzzinessCantBeMoreThanOne(t *testing.T) {
 		materials.NewReflectiveFuzzy(MATERIAL_COLOR, 1.5, random.NewRandomGenerator())
 	})
 }
+
+func TestReflective_BRDFShouldBeBlack(t *testing.T) {
+	material := materials.NewReflective(MATERIAL_COLOR, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(4, -3, 0)
+
+	brdf := material.BRDF(incidentDirection, core.NewVec3(4, 3, 0), SURFACE)
+
+	assert.Equal(t, color.Black, brdf)
+}
+
+func TestReflective_PDFShouldBeZero_WhenNotFuzzy(t *testing.T) {
+	material := materials.NewReflective(MATERIAL_COLOR, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(4, -3, 0)
+
+	pdf := material.PDF(incidentDirection, core.NewVec3(4, 3, 0), SURFACE)
+
+	assert.EqualValues(t, 0, pdf)
+}
+
+func TestReflective_PDFShouldIntegrateToOne_WhenFuzzy(t *testing.T) {
+	material := materials.NewReflectiveFuzzy(MATERIAL_COLOR, 0.5, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(4, -3, 0)
+
+	integral := integrateOverSphere(func(direction core.Vec3) core.Real {
+		return material.PDF(incidentDirection, direction, SURFACE)
+	})
+
+	assert.InDelta(t, 1, integral, 1e-2)
+}
+
+func TestReflective_BRDFShouldMatchPDF_WhenFuzzy(t *testing.T) {
+	material := materials.NewReflectiveFuzzy(MATERIAL_COLOR, 0.5, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(4, -3, 0)
+	scatteredDirection := core.NewVec3(4, 3.5, 0.2)
+
+	brdf := material.BRDF(incidentDirection, scatteredDirection, SURFACE)
+	pdf := material.PDF(incidentDirection, scatteredDirection, SURFACE)
+
+	cosine := scatteredDirection.Normalize().Dot(NORMAL_AT_HIT_POINT)
+	assert.Greater(t, pdf, core.Real(0))
+	expected, result := MATERIAL_COLOR.Mul(pdf), brdf.Mul(cosine)
+	assert.InDelta(t, expected.R(), result.R(), 1e-5)
+	assert.InDelta(t, expected.G(), result.G(), 1e-5)
+	assert.InDelta(t, expected.B(), result.B(), 1e-5)
+}
+
+// Midpoint rule in spherical coordinates
+func integrateOverSphere(function func(direction core.Vec3) core.Real) core.Real {
+	const thetaSteps, phiSteps = 400, 800
+	dTheta, dPhi := core.Pi/thetaSteps, 2*core.Pi/phiSteps
+
+	integral := core.Real(0)
+	for i := 0; i < thetaSteps; i++ {
+		theta := (core.Real(i) + 0.5) * dTheta
+		for j := 0; j < phiSteps; j++ {
+			phi := (core.Real(j) + 0.5) * dPhi
+			direction := core.NewVec3(core.Sin(theta)*core.Cos(phi), core.Cos(theta), core.Sin(theta)*core.Sin(phi))
+			integral += function(direction) * core.Sin(theta) * dTheta * dPhi
+		}
+	}
+	return integral
+}
+
+func TestReflective_ShouldTakeColorFromTexture(t *testing.T) {
+	material := materials.NewReflectiveTextured(checkerTexture(), 0, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(4, -3, 0)
+	blackSquare := core.SurfacePoint{Point: HIT_POINT, Normal: NORMAL_AT_HIT_POINT, UV: core.NewVec2(1.5, 0.5)}
+
+	reflection := material.Reflect(incidentDirection, blackSquare)
+
+	assert.Equal(t, color.Black, reflection.Color)
+}
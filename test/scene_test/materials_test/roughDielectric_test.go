@@ -0,0 +1,101 @@
+package materials_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoughDielectric_ShouldMatchTransparent_WhenSmooth(t *testing.T) {
+	for _, randomValue := range []core.Real{0, 0.99} {
+		randomizer := random.FakeRandomGenerator{RealValue: randomValue}
+		transparent := materials.NewTransparent(GLASS_REFRACTION_INDEX, MATERIAL_COLOR, randomizer)
+		material := materials.NewRoughDielectric(GLASS_REFRACTION_INDEX, 0, MATERIAL_COLOR, randomizer)
+
+		for _, incidentDirection := range []core.Vec3{INCIDENT_DIRECTION, INCIDENT_DIRECTION.Mul(-1)} {
+			expected := transparent.Reflect(incidentDirection, SURFACE)
+			reflection := material.Reflect(incidentDirection, SURFACE)
+
+			assert.Equal(t, expected.Type, reflection.Type)
+			test.AssertInDeltaVec3(t, expected.Ray.Direction().Normalize(), reflection.Ray.Direction().Normalize(), core.Tolerance)
+			assert.Equal(t, MATERIAL_COLOR, reflection.Color)
+		}
+	}
+}
+
+func TestRoughDielectric_ShouldHaveNoBRDFAndPDF_WhenSmooth(t *testing.T) {
+	material := materials.NewRoughDielectric(GLASS_REFRACTION_INDEX, 0, MATERIAL_COLOR, random.NewRandomGenerator())
+
+	assert.Equal(t, color.Black, material.BRDF(INCIDENT_DIRECTION, REFLECTED_DIRECTION, SURFACE))
+	assert.EqualValues(t, 0, material.PDF(INCIDENT_DIRECTION, REFLECTED_DIRECTION, SURFACE))
+}
+
+func TestRoughDielectric_PDFShouldIntegrateToAlmostOne(t *testing.T) {
+	material := materials.NewRoughDielectric(GLASS_REFRACTION_INDEX, 0.5, color.White, random.NewRandomGenerator())
+
+	for _, incidentDirection := range []core.Vec3{core.NewVec3(1, -2, 0), core.NewVec3(1, 2, 0)} {
+		integral := integrateOverSphere(func(direction core.Vec3) core.Real {
+			return material.PDF(incidentDirection, direction, SURFACE)
+		})
+
+		// Some of the sampled directions end up on the wrong side of the surface and are absorbed
+		assert.InDelta(t, 0.97, integral, 0.03)
+	}
+}
+
+func TestRoughDielectric_SampleWeightShouldMatchBRDFAndPDF(t *testing.T) {
+	material := materials.NewRoughDielectric(GLASS_REFRACTION_INDEX, 0.4, color.White, random.NewRandomGenerator())
+
+	reflections, refractions := 0, 0
+	for _, incidentDirection := range []core.Vec3{core.NewVec3(2, -1, 1), core.NewVec3(1, 3, 0)} {
+		for i := 0; i < 200; i++ {
+			reflection := material.Reflect(incidentDirection, SURFACE)
+			if reflection.Type == materials.Absorbed {
+				continue
+			}
+
+			direction := reflection.Ray.Direction()
+			if direction.Dot(NORMAL_AT_HIT_POINT)*incidentDirection.Dot(NORMAL_AT_HIT_POINT) < 0 {
+				reflections++
+			} else {
+				refractions++
+			}
+
+			brdf := material.BRDF(incidentDirection, direction, SURFACE)
+			pdf := material.PDF(incidentDirection, direction, SURFACE)
+			cosine := core.Abs(direction.Normalize().Dot(NORMAL_AT_HIT_POINT))
+			assert.InDelta(t, reflection.Color.R(), brdf.R()*cosine/pdf, 1e-2)
+		}
+	}
+	assert.Greater(t, reflections, 0)
+	assert.Greater(t, refractions, 0)
+}
+
+func TestRoughDielectric_SamplingShouldEstimateAlbedo(t *testing.T) {
+	material := materials.NewRoughDielectric(GLASS_REFRACTION_INDEX, 0.6, color.White, random.NewRandomGenerator())
+	incidentDirection := core.NewVec3(1, -1, 0)
+
+	albedo := integrateOverSphere(func(direction core.Vec3) core.Real {
+		cosine := core.Abs(direction.Dot(NORMAL_AT_HIT_POINT))
+		return material.BRDF(incidentDirection, direction, SURFACE).R() * cosine
+	})
+
+	const samples = 20000
+	estimate := core.Real(0)
+	for i := 0; i < samples; i++ {
+		estimate += material.Reflect(incidentDirection, SURFACE).Color.R() / samples
+	}
+
+	assert.InDelta(t, albedo, estimate, 0.02)
+	assert.Less(t, albedo, core.Real(1))
+}
+
+func TestRoughDielectric_ShouldPanic_IfSettingsInvalid(t *testing.T) {
+	assert.Panics(t, func() { materials.NewRoughDielectric(0.5, 0.5, color.White, random.NewRandomGenerator()) })
+	assert.Panics(t, func() { materials.NewRoughDielectric(1.5, 2, color.White, random.NewRandomGenerator()) })
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
 	"github.com/Shamanskiy/go-ray-tracer/src/core/random"
 	"github.com/Shamanskiy/go-ray-tracer/src/scene/materials"
 	"github.com/Shamanskiy/go-ray-tracer/test"
@@ -25,7 +26,7 @@ func TestTransparent_ShouldReturnRefractedRay_WhenRandomReturnsOne(t *testing.T)
 	randomizer.RealValue = 1
 	material := materials.NewTransparent(GLASS_REFRACTION_INDEX, MATERIAL_COLOR, randomizer)
 
-	reflection := material.Reflect(INCIDENT_DIRECTION, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(INCIDENT_DIRECTION, SURFACE)
 
 	test.AssertInDeltaVec3(t, REFRACTED_DIRECTION, reflection.Ray.Direction(), core.Tolerance)
 	assert.Equal(t, HIT_POINT, reflection.Ray.Origin())
@@ -38,7 +39,7 @@ func TestTransparent_ShouldReturnReflectedRay_WhenRandomReturnsZero(t *testing.T
 	randomizer.RealValue = 0
 	material := materials.NewTransparent(GLASS_REFRACTION_INDEX, MATERIAL_COLOR, randomizer)
 
-	reflection := material.Reflect(INCIDENT_DIRECTION, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(INCIDENT_DIRECTION, SURFACE)
 
 	assert.Equal(t, REFLECTED_DIRECTION, reflection.Ray.Direction())
 	assert.Equal(t, HIT_POINT, reflection.Ray.Origin())
@@ -49,7 +50,7 @@ func TestTransparent_ShouldReturnReflectedRay_WhenRandomReturnsZero(t *testing.T
 func TestTransparent_ShouldReturnRefractedOrReflectedRay_WhenRandomEnabled(t *testing.T) {
 	material := materials.NewTransparent(GLASS_REFRACTION_INDEX, MATERIAL_COLOR, random.NewRandomGenerator())
 
-	reflection := material.Reflect(INCIDENT_DIRECTION, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(INCIDENT_DIRECTION, SURFACE)
 
 	assert.True(t, reflection.Ray.Direction().InDelta(REFLECTED_DIRECTION, core.Tolerance) ||
 		reflection.Ray.Direction().InDelta(REFRACTED_DIRECTION, core.Tolerance))
@@ -62,7 +63,7 @@ func TestTransparent_ShouldReturnReflectedRay_WhenRayExitsMaterialAtTooLargeAngl
 	material := materials.NewTransparent(GLASS_REFRACTION_INDEX, MATERIAL_COLOR, random.NewRandomGenerator())
 	incidentDirection := core.NewVec3(1, 1, 0)
 
-	reflection := material.Reflect(incidentDirection, HIT_POINT, NORMAL_AT_HIT_POINT)
+	reflection := material.Reflect(incidentDirection, SURFACE)
 
 	reflectedDirection := core.NewVec3(1, -1, 0)
 	assert.Equal(t, reflectedDirection, reflection.Ray.Direction())
@@ -70,3 +71,32 @@ func TestTransparent_ShouldReturnReflectedRay_WhenRayExitsMaterialAtTooLargeAngl
 	assert.Equal(t, MATERIAL_COLOR, reflection.Color)
 	assert.Equal(t, materials.Scattered, reflection.Type)
 }
+
+func TestTransparent_ShouldHaveNoBRDFAndPDF(t *testing.T) {
+	material := materials.NewTransparent(1.5, MATERIAL_COLOR, random.NewRandomGenerator())
+
+	assert.Equal(t, color.Black, material.BRDF(INCIDENT_DIRECTION, REFLECTED_DIRECTION, SURFACE))
+	assert.EqualValues(t, 0, material.PDF(INCIDENT_DIRECTION, REFLECTED_DIRECTION, SURFACE))
+}
+
+func TestTransparent_ShouldAttenuateByDistance(t *testing.T) {
+	material := materials.NewTransparentAbsorbing(GLASS_REFRACTION_INDEX, MATERIAL_COLOR, color.New(0, 1, 2), random.NewRandomGenerator())
+
+	transmittance := material.Transmittance(0.5)
+
+	assert.InDelta(t, 1, transmittance.R(), core.Tolerance)
+	assert.InDelta(t, core.Exp(-0.5), transmittance.G(), core.Tolerance)
+	assert.InDelta(t, core.Exp(-1), transmittance.B(), core.Tolerance)
+}
+
+func TestTransparent_ShouldNotAbsorb_ByDefault(t *testing.T) {
+	var material materials.Absorbing = materials.NewTransparent(GLASS_REFRACTION_INDEX, MATERIAL_COLOR, random.NewRandomGenerator())
+
+	assert.Equal(t, color.White, material.Transmittance(100))
+}
+
+func TestTransparent_AbsorptionCantBeNegative(t *testing.T) {
+	assert.Panics(t, func() {
+		materials.NewTransparentAbsorbing(GLASS_REFRACTION_INDEX, MATERIAL_COLOR, color.New(0, -1, 0), random.NewRandomGenerator())
+	})
+}
@@ -80,6 +80,16 @@ func TestScene_ShouldColorRayBlack_IfMaxNumberOfReflectionsExceeded(t *testing.T
 	assert.Equal(t, expectedColor, rayColor)
 }
 
+func TestScene_ShouldConfigurePathTracer_WithUseIntegrator(t *testing.T) {
+	pathTracer := scene.NewPathTracer(scene.PathTracerMaxRayReflections(1))
+	scene := scene.New(reflectiveXYAngle(), flatBackground(), scene.UseIntegrator(pathTracer))
+	ray := core.NewRay(core.NewVec3(2, 1, 0), core.NewVec3(-1, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.Black, rayColor)
+}
+
 func flatBackground() background.Background {
 	return background.NewFlatColor(BACKGROUND_COLOR)
 }
@@ -121,3 +131,317 @@ func unitSphere(materialColor color.Color, translation ...core.Vec3) scene.Objec
 	material := materials.NewDiffusive(materialColor, randomizer)
 	return scene.Object{Hittable: sphere, Material: material}
 }
+
+func TestScene_ShouldHitClosestObject_WithMedianSplitBVH(t *testing.T) {
+	objects := []scene.Object{unitSphere(OBJECT_COLOR), unitSphere(OTHER_OBJECT_COLOR, core.NewVec3(-10, 0, 0))}
+	scene := scene.New(objects, flatBackground(), scene.BVHSplitMethod(geometries.SplitMedian))
+	ray := core.NewRay(core.NewVec3(2, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, OBJECT_COLOR.MulColor(BACKGROUND_COLOR), rayColor)
+	assert.Equal(t, 2, scene.BVHStats().LeafCount)
+}
+
+func TestScene_ShouldSampleLightDirectly_WithDirectLightSampling(t *testing.T) {
+	objects := []scene.Object{diffusiveFloor(), sphereLight(core.NewVec3(0, 2, 0))}
+	scene := scene.New(objects, background.NewFlatColor(color.Black),
+		scene.DirectLightSampling(random.NewFakeRandomGenerator()))
+	ray := core.NewRay(core.NewVec3(0, 1, 0), core.NewVec3(0, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	// Both the light sample and the scattered ray go straight up and hit the light.
+	// Their estimates are weighted with the power heuristic.
+	lightPDF := 1 / (2 * core.Pi * (1 - core.Sqrt(0.75)))
+	materialPDF := 1 / core.Pi
+	lightWeight := lightPDF * lightPDF / (lightPDF*lightPDF + materialPDF*materialPDF)
+	lightEstimate := materialPDF / lightPDF
+	expectedColor := OBJECT_COLOR.Mul(lightWeight*lightEstimate + (1 - lightWeight))
+	assertColorsInDelta(t, expectedColor, rayColor, 1e-5)
+}
+
+func TestScene_ShouldHitLightByScattering_WithoutDirectLightSampling(t *testing.T) {
+	objects := []scene.Object{diffusiveFloor(), sphereLight(core.NewVec3(0, 2, 0))}
+	scene := scene.New(objects, background.NewFlatColor(color.Black))
+	ray := core.NewRay(core.NewVec3(0, 1, 0), core.NewVec3(0, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, OBJECT_COLOR, rayColor)
+}
+
+func TestScene_ShouldNotSampleOccludedLight(t *testing.T) {
+	blocker := scene.Object{
+		Hittable: geometries.NewSphere(core.NewVec3(0, 1, 0), 0.1),
+		Material: materials.NewReflective(OBJECT_COLOR, randomizer),
+	}
+	objects := []scene.Object{diffusiveFloor(), sphereLight(core.NewVec3(0, 2, 0)), blocker}
+	scene := scene.New(objects, background.NewFlatColor(color.Black),
+		scene.DirectLightSampling(random.NewFakeRandomGenerator()))
+	ray := core.NewRay(core.NewVec3(1, 1, 0), core.NewVec3(-1, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.Black, rayColor)
+}
+
+func TestScene_ShouldFindLightByScattering_IfLightCantBeSampled(t *testing.T) {
+	light := scene.Object{
+		Hittable: geometries.NewTranslation(geometries.NewSphere(core.NewVec3(0, 0, 0), 1), core.NewVec3(0, 2, 0)),
+		Material: materials.NewDiffusiveLight(color.White, 1),
+	}
+	objects := []scene.Object{diffusiveFloor(), light}
+	scene := scene.New(objects, background.NewFlatColor(color.Black),
+		scene.DirectLightSampling(random.NewFakeRandomGenerator()))
+	ray := core.NewRay(core.NewVec3(0, 1, 0), core.NewVec3(0, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, OBJECT_COLOR, rayColor)
+}
+
+func diffusiveFloor() scene.Object {
+	floor := geometries.NewQuad(
+		core.NewVec3(-5, 0, -5),
+		core.NewVec3(-5, 0, 5),
+		core.NewVec3(5, 0, 5),
+		core.NewVec3(5, 0, -5))
+	return scene.Object{Hittable: floor, Material: materials.NewDiffusive(OBJECT_COLOR, random.NewFakeRandomGenerator())}
+}
+
+func sphereLight(center core.Vec3) scene.Object {
+	return scene.Object{Hittable: geometries.NewSphere(center, 1), Material: materials.NewDiffusiveLight(color.White, 1)}
+}
+
+func assertColorsInDelta(t *testing.T, expected, result color.Color, delta float64) {
+	assert.InDelta(t, expected.R(), result.R(), delta)
+	assert.InDelta(t, expected.G(), result.G(), delta)
+	assert.InDelta(t, expected.B(), result.B(), delta)
+}
+
+func TestScene_ShouldCountLightFully_WhenSeenInMirror(t *testing.T) {
+	mirror := scene.Object{Hittable: diffusiveFloor().Hittable, Material: materials.NewReflective(OBJECT_COLOR, randomizer)}
+	objects := []scene.Object{mirror, sphereLight(core.NewVec3(1, 2, 0))}
+	scene := scene.New(objects, background.NewFlatColor(color.Black), scene.DirectLightSampling(randomizer))
+	ray := core.NewRay(core.NewVec3(-1, 2, 0), core.NewVec3(1, -2, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, OBJECT_COLOR, rayColor)
+}
+
+func TestScene_RussianRouletteShouldTerminateDimRay(t *testing.T) {
+	dimColor := color.New(0.1, 0.1, 0.1)
+	scene := scene.New([]scene.Object{unitSphere(dimColor)}, flatBackground(),
+		scene.RussianRoulette(1, 0.05, random.FakeRandomGenerator{RealValue: 0.5}))
+	ray := core.NewRay(core.NewVec3(2, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.Black, rayColor)
+}
+
+func TestScene_RussianRouletteShouldCompensateSurvivingRay(t *testing.T) {
+	dimColor := color.New(0.1, 0.1, 0.1)
+	scene := scene.New([]scene.Object{unitSphere(dimColor)}, flatBackground(),
+		scene.RussianRoulette(1, 0.05, random.FakeRandomGenerator{RealValue: 0}))
+	ray := core.NewRay(core.NewVec3(2, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assertColorsInDelta(t, BACKGROUND_COLOR, rayColor, 1e-5)
+}
+
+func TestScene_RussianRouletteShouldNotApplyBeforeStartDepth(t *testing.T) {
+	dimColor := color.New(0.1, 0.1, 0.1)
+	scene := scene.New([]scene.Object{unitSphere(dimColor)}, flatBackground(),
+		scene.RussianRoulette(2, 0.05, random.FakeRandomGenerator{RealValue: 0.5}))
+	ray := core.NewRay(core.NewVec3(2, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, dimColor.MulColor(BACKGROUND_COLOR), rayColor)
+}
+
+func TestScene_RussianRouletteShouldKeepItsRandomizer_WithDirectLightSampling(t *testing.T) {
+	dimColor := color.New(0.1, 0.1, 0.1)
+	scene := scene.New([]scene.Object{unitSphere(dimColor)}, flatBackground(),
+		scene.RussianRoulette(1, 0.05, random.FakeRandomGenerator{RealValue: 0.5}),
+		scene.DirectLightSampling(random.FakeRandomGenerator{RealValue: 0}))
+	ray := core.NewRay(core.NewVec3(2, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assert.Equal(t, color.Black, rayColor)
+}
+
+func TestScene_RussianRouletteShouldValidateSettings(t *testing.T) {
+	assert.Panics(t, func() { scene.RussianRoulette(-1, 0.05, randomizer) })
+	assert.Panics(t, func() { scene.RussianRoulette(3, 0, randomizer) })
+	assert.Panics(t, func() { scene.RussianRoulette(3, 1.5, randomizer) })
+}
+
+func TestScene_ShouldAbsorbLightByDistanceInsideGlass(t *testing.T) {
+	absorption := color.New(0, 0, 0.5)
+	objects := []scene.Object{absorbingGlassSphere(core.NewVec3(0, 0, 0), 1, absorption)}
+	scene := scene.New(objects, flatBackground())
+	ray := core.NewRay(core.NewVec3(3, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	assertColorsInDelta(t, BACKGROUND_COLOR.Mul(core.Exp(-1)), rayColor, 1e-5)
+}
+
+func TestScene_ShouldAbsorbMoreLightInThickerGlass(t *testing.T) {
+	absorption := color.New(0, 0, 0.5)
+	thin := scene.New([]scene.Object{absorbingGlassSphere(core.NewVec3(0, 0, 0), 1, absorption)}, flatBackground())
+	thick := scene.New([]scene.Object{absorbingGlassSphere(core.NewVec3(0, 0, 0), 2, absorption)}, flatBackground())
+	ray := core.NewRay(core.NewVec3(3, 0, 0), core.NewVec3(-1, 0, 0))
+
+	assert.Greater(t, thin.TestRay(ray).B(), thick.TestRay(ray).B())
+}
+
+func TestScene_ShouldNotAbsorbLightReflectedOffGlass(t *testing.T) {
+	reflecting := random.FakeRandomGenerator{RealValue: 0}
+	glass := materials.NewTransparentAbsorbing(1.5, color.White, color.New(1, 1, 1), reflecting)
+	objects := []scene.Object{{Hittable: geometries.NewSphere(core.NewVec3(0, 0, 0), 1), Material: glass}}
+	scene := scene.New(objects, flatBackground())
+	ray := core.NewRay(core.NewVec3(3, 0, 0), core.NewVec3(-1, 0, 0))
+
+	assert.Equal(t, BACKGROUND_COLOR, scene.TestRay(ray))
+}
+
+// Rays always refract, straight through the center at normal incidence.
+func absorbingGlassSphere(center core.Vec3, radius core.Real, absorption color.Color) scene.Object {
+	refracting := random.FakeRandomGenerator{RealValue: 1}
+	glass := materials.NewTransparentAbsorbing(1.5, color.White, absorption, refracting)
+	return scene.Object{Hittable: geometries.NewSphere(center, radius), Material: glass}
+}
+
+func TestScene_ShouldAttenuateLightThroughMedium(t *testing.T) {
+	medium := geometries.NewConstantMedium(geometries.NewSphere(core.NewVec3(0, 0, 0), 1), 0.5, randomizer)
+	objects := []scene.Object{{Hittable: medium, Material: materials.NewIsotropic(color.Black, randomizer)}}
+	scene := scene.New(objects, background.NewFlatColor(color.White))
+	ray := core.NewRay(core.NewVec3(3, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := averageRayColor(scene, ray, 20000)
+
+	assert.InDelta(t, core.Exp(-1), rayColor.R(), 0.02)
+}
+
+func TestScene_ShouldConserveLightInWhiteMedium(t *testing.T) {
+	medium := geometries.NewConstantMedium(geometries.NewSphere(core.NewVec3(0, 0, 0), 1), 0.5, randomizer)
+	objects := []scene.Object{{Hittable: medium, Material: materials.NewHenyeyGreenstein(color.White, 0.3, randomizer)}}
+	scene := scene.New(objects, background.NewFlatColor(color.White),
+		scene.DirectLightSampling(randomizer))
+	ray := core.NewRay(core.NewVec3(3, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := averageRayColor(scene, ray, 5000)
+
+	assert.InDelta(t, 1, rayColor.R(), 0.02)
+}
+
+func TestScene_ShouldAttenuateLightInFog(t *testing.T) {
+	objects := []scene.Object{unitSphere(OBJECT_COLOR), unitSphere(OBJECT_COLOR, core.NewVec3(4, 0, 0))}
+	fog := scene.BoundedFog(0.5, materials.NewIsotropic(color.Black, randomizer), randomizer)
+	scene := scene.New(objects, background.NewFlatColor(color.White), fog)
+	// Passes between the spheres through 2 units of fog
+	ray := core.NewRay(core.NewVec3(2, 0, -10), core.NewVec3(0, 0, 1))
+
+	rayColor := averageRayColor(scene, ray, 20000)
+
+	assert.InDelta(t, core.Exp(-1), rayColor.R(), 0.02)
+}
+
+func TestScene_FogShouldNotAffectRaysOutsideSceneBounds(t *testing.T) {
+	fog := scene.BoundedFog(100, materials.NewIsotropic(color.Black, randomizer), randomizer)
+	scene := scene.New([]scene.Object{unitSphere(OBJECT_COLOR)}, flatBackground(), fog)
+	ray := core.NewRay(core.NewVec3(0, 5, -10), core.NewVec3(0, 0, 1))
+
+	assert.Equal(t, BACKGROUND_COLOR, scene.TestRay(ray))
+}
+
+func TestScene_FogShouldNotFillSpaceAroundPlane(t *testing.T) {
+	ground := scene.Object{
+		Hittable: geometries.NewPlane(core.NewVec3(0, -1, 0), core.NewVec3(0, 1, 0)),
+		Material: materials.NewDiffusive(OBJECT_COLOR, randomizer),
+	}
+	fog := scene.BoundedFog(100, materials.NewIsotropic(color.Black, randomizer), randomizer)
+	scene := scene.New([]scene.Object{ground, unitSphere(OBJECT_COLOR)}, flatBackground(), fog)
+	// Skims over the plane, outside of the bounding box of the sphere
+	escaping := core.NewRay(core.NewVec3(5, 0, -10), core.NewVec3(0, 0, 1))
+	// Misses the sphere, but passes through the fog in its bounding box
+	nearSphere := core.NewRay(core.NewVec3(0.9, 0.9, -10), core.NewVec3(0, 0, 1))
+
+	assert.Equal(t, BACKGROUND_COLOR, scene.TestRay(escaping))
+	assert.Equal(t, color.Black, scene.TestRay(nearSphere))
+}
+
+func TestScene_ShouldHaveNoFog_IfSceneIsEmpty(t *testing.T) {
+	fog := scene.BoundedFog(100, materials.NewIsotropic(color.Black, randomizer), randomizer)
+	scene := scene.New([]scene.Object{}, flatBackground(), fog)
+	ray := core.NewRay(core.NewVec3(0, 0, -10), core.NewVec3(0, 0, 1))
+
+	assert.Equal(t, BACKGROUND_COLOR, scene.TestRay(ray))
+}
+
+func TestScene_FogDensityMustBePositive(t *testing.T) {
+	assert.Panics(t, func() { scene.BoundedFog(0, materials.NewIsotropic(color.White, randomizer), randomizer) })
+}
+
+func averageRayColor(scene *scene.SceneImpl, ray core.Ray, samples int) color.Color {
+	sum := color.Black
+	for i := 0; i < samples; i++ {
+		sum = sum.Add(scene.TestRay(ray))
+	}
+	return sum.Div(core.Real(samples))
+}
+
+func TestScene_ShouldAttenuateLightThroughGridVolume(t *testing.T) {
+	grid := geometries.NewDensityGrid([3]int{1, 1, 1}, core.NewBox(core.NewVec3(-1, -1, -1), core.NewVec3(1, 1, 1)), []core.Real{1})
+	volume := geometries.NewGridVolume(grid, 0.25, randomizer)
+	objects := []scene.Object{{Hittable: volume, Material: materials.NewIsotropic(color.Black, randomizer)}}
+	scene := scene.New(objects, background.NewFlatColor(color.White))
+	ray := core.NewRay(core.NewVec3(3, 0, 0), core.NewVec3(-1, 0, 0))
+
+	rayColor := averageRayColor(scene, ray, 20000)
+
+	assert.InDelta(t, core.Exp(-0.5), rayColor.R(), 0.02)
+}
+
+func TestScene_ShouldAttenuateSampledLightThroughMedium(t *testing.T) {
+	// Between the floor and the light, but away from the camera ray
+	boundary := geometries.NewSphere(core.NewVec3(0, 0.5, 0), 0.3)
+	passing := random.FakeRandomGenerator{RealValue: 0.999}
+	medium := scene.Object{
+		Hittable: geometries.NewConstantMedium(boundary, 1, passing),
+		Material: materials.NewIsotropic(color.Black, randomizer),
+	}
+	objects := []scene.Object{diffusiveFloor(), sphereLight(core.NewVec3(0, 2, 0)), medium}
+	scene := scene.New(objects, background.NewFlatColor(color.Black),
+		scene.DirectLightSampling(random.NewFakeRandomGenerator()))
+	ray := core.NewRay(core.NewVec3(1, 1, 0), core.NewVec3(-1, -1, 0))
+
+	rayColor := scene.TestRay(ray)
+
+	// As without the medium, but the light sample passes through 0.6 units of it
+	lightPDF := 1 / (2 * core.Pi * (1 - core.Sqrt(0.75)))
+	materialPDF := 1 / core.Pi
+	lightWeight := lightPDF * lightPDF / (lightPDF*lightPDF + materialPDF*materialPDF)
+	lightEstimate := materialPDF / lightPDF
+	expectedColor := OBJECT_COLOR.Mul(lightWeight*lightEstimate*core.Exp(-0.6) + (1 - lightWeight))
+	assertColorsInDelta(t, expectedColor, rayColor, 1e-4)
+}
+
+func TestScene_ScatteredRaysShouldKeepRayTime(t *testing.T) {
+	mirror := scene.Object{Hittable: diffusiveFloor().Hittable, Material: materials.NewReflective(OBJECT_COLOR, randomizer)}
+	movingLight := scene.Object{
+		Hittable: geometries.NewMovingSphere(core.NewVec3(1, 2, 0), core.NewVec3(1, 2, 10), 0, 1, 1),
+		Material: materials.NewDiffusiveLight(color.White, 1)}
+	scene := scene.New([]scene.Object{mirror, movingLight}, background.NewFlatColor(color.Black))
+	origin, direction := core.NewVec3(-1, 2, 0), core.NewVec3(1, -2, 0)
+
+	assert.Equal(t, OBJECT_COLOR, scene.TestRay(core.NewRayAtTime(origin, direction, 0)))
+	assert.Equal(t, color.Black, scene.TestRay(core.NewRayAtTime(origin, direction, 1)))
+}
@@ -0,0 +1,31 @@
+package textures_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckerboard_ShouldAlternateTextures(t *testing.T) {
+	texture := textures.NewCheckerboard(textures.NewConstant(color.White), textures.NewConstant(color.Black), 2)
+
+	assert.Equal(t, color.White, texture.ColorAt(surfaceAt(0.1, 0.1)))
+	assert.Equal(t, color.Black, texture.ColorAt(surfaceAt(0.6, 0.1)))
+	assert.Equal(t, color.Black, texture.ColorAt(surfaceAt(0.1, 0.6)))
+	assert.Equal(t, color.White, texture.ColorAt(surfaceAt(0.6, 0.6)))
+}
+
+func TestCheckerboard_ShouldContinueOutsideUnitSquare(t *testing.T) {
+	texture := textures.NewCheckerboard(textures.NewConstant(color.White), textures.NewConstant(color.Black), 1)
+
+	assert.Equal(t, color.Black, texture.ColorAt(surfaceAt(-0.5, 0.5)))
+	assert.Equal(t, color.White, texture.ColorAt(surfaceAt(-0.5, -0.5)))
+}
+
+func TestCheckerboard_ShouldPanic_IfFrequencyNotPositive(t *testing.T) {
+	assert.Panics(t, func() {
+		textures.NewCheckerboard(textures.NewConstant(color.White), textures.NewConstant(color.Black), 0)
+	})
+}
@@ -0,0 +1,22 @@
+package textures_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstant_ShouldReturnSameColorEverywhere(t *testing.T) {
+	texture := textures.NewConstant(color.Red)
+
+	assert.Equal(t, color.Red, texture.ColorAt(surfaceAt(0, 0)))
+	assert.Equal(t, color.Red, texture.ColorAt(surfaceAt(0.3, 0.7)))
+	assert.Equal(t, color.Red, texture.ColorAt(surfaceAt(-5, 12)))
+}
+
+func surfaceAt(u, v core.Real) core.SurfacePoint {
+	return core.SurfacePoint{UV: core.NewVec2(u, v)}
+}
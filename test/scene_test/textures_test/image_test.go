@@ -0,0 +1,98 @@
+package textures_test
+
+import (
+	"image"
+	rgba "image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/Shamanskiy/go-ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// Black and white on the top row, red and blue on the bottom one.
+func twoByTwoImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, rgba.RGBA{0, 0, 0, 255})
+	img.Set(1, 0, rgba.RGBA{255, 255, 255, 255})
+	img.Set(0, 1, rgba.RGBA{255, 0, 0, 255})
+	img.Set(1, 1, rgba.RGBA{0, 0, 255, 255})
+	return img
+}
+
+func TestImage_ShouldReturnPixelColorsAtPixelCenters(t *testing.T) {
+	texture := textures.NewImage(twoByTwoImage(), textures.WrapClamp)
+
+	assertColorInDelta(t, color.Black, texture.ColorAt(surfaceAt(0.25, 0.75)))
+	assertColorInDelta(t, color.White, texture.ColorAt(surfaceAt(0.75, 0.75)))
+	assertColorInDelta(t, color.Red, texture.ColorAt(surfaceAt(0.25, 0.25)))
+	assertColorInDelta(t, color.Blue, texture.ColorAt(surfaceAt(0.75, 0.25)))
+}
+
+func TestImage_ShouldInterpolateBilinearly(t *testing.T) {
+	texture := textures.NewImage(twoByTwoImage(), textures.WrapClamp)
+
+	assertColorInDelta(t, color.GrayMedium, texture.ColorAt(surfaceAt(0.5, 0.75)))
+	assertColorInDelta(t, color.New(0.5, 0.25, 0.5), texture.ColorAt(surfaceAt(0.5, 0.5)))
+}
+
+func TestImage_ShouldWrapTextureCoordinates(t *testing.T) {
+	testCases := []struct {
+		wrapMode   textures.WrapMode
+		expectedAt [3]color.Color
+	}{
+		{textures.WrapRepeat, [3]color.Color{color.Black, color.White, color.White}},
+		{textures.WrapClamp, [3]color.Color{color.White, color.White, color.Black}},
+		{textures.WrapMirror, [3]color.Color{color.White, color.Black, color.Black}},
+	}
+
+	for _, testCase := range testCases {
+		texture := textures.NewImage(twoByTwoImage(), testCase.wrapMode)
+		assertColorInDelta(t, testCase.expectedAt[0], texture.ColorAt(surfaceAt(1.25, 0.75)))
+		assertColorInDelta(t, testCase.expectedAt[1], texture.ColorAt(surfaceAt(1.75, 0.75)))
+		assertColorInDelta(t, testCase.expectedAt[2], texture.ColorAt(surfaceAt(-0.25, 0.75)))
+	}
+}
+
+func TestImage_ShouldPanic_IfWrapModeInvalid(t *testing.T) {
+	assert.Panics(t, func() { textures.NewImage(twoByTwoImage(), textures.WrapMode(42)) })
+}
+
+func TestImage_ShouldLoadPNGFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "texture.png")
+	file, err := os.Create(filename)
+	test.PanicOnErr(err)
+	test.PanicOnErr(png.Encode(file, twoByTwoImage()))
+	test.PanicOnErr(file.Close())
+
+	texture, err := textures.LoadImageFile(filename, textures.WrapRepeat)
+
+	assert.NoError(t, err)
+	assertColorInDelta(t, color.Blue, texture.ColorAt(surfaceAt(0.75, 0.25)))
+}
+
+func TestImage_ShouldReturnError_IfFileMissing(t *testing.T) {
+	_, err := textures.LoadImageFile(filepath.Join(t.TempDir(), "missing.png"), textures.WrapRepeat)
+
+	assert.Error(t, err)
+}
+
+func assertColorInDelta(t *testing.T, expected, actual color.Color) {
+	assert.InDelta(t, expected.R(), actual.R(), core.Tolerance, "red channel")
+	assert.InDelta(t, expected.G(), actual.G(), core.Tolerance, "green channel")
+	assert.InDelta(t, expected.B(), actual.B(), core.Tolerance, "blue channel")
+}
+
+func TestLinearImage_ShouldKeepPixelValues(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, rgba.RGBA{128, 255, 0, 255})
+
+	texture := textures.NewLinearImage(img, textures.WrapRepeat)
+
+	assertColorInDelta(t, color.New(128./255, 1, 0), texture.ColorAt(surfaceAt(0.5, 0.5)))
+}
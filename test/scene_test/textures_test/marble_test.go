@@ -0,0 +1,28 @@
+package textures_test
+
+import (
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/noise"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarble_ShouldFollowSineWave_WithoutTurbulence(t *testing.T) {
+	texture := textures.NewMarble(noise.NewPerlin(SEED), 1, 0, color.White, color.Black)
+
+	assertColorInDelta(t, color.Black, texture.ColorAt(surfaceAtPoint(0, 1, 2)))
+	assertColorInDelta(t, color.White, texture.ColorAt(surfaceAtPoint(core.Pi/2, 1, 2)))
+}
+
+func TestMarble_ShouldVaryWithTurbulence(t *testing.T) {
+	texture := textures.NewMarble(noise.NewPerlin(SEED), 3, 5, color.White, color.Black)
+
+	assertColorsVary(t, texture)
+}
+
+func TestMarble_ShouldPanic_IfTurbulenceNegative(t *testing.T) {
+	assert.Panics(t, func() { textures.NewMarble(noise.NewPerlin(SEED), 1, -1, color.White, color.Black) })
+}
@@ -0,0 +1,47 @@
+package textures_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/noise"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/stretchr/testify/assert"
+)
+
+const SEED = 7
+
+func TestNoise_ShouldBlendColorsByPoint(t *testing.T) {
+	texture := textures.NewNoise(noise.NewPerlin(SEED), 4, color.Black, color.White)
+
+	assertColorInDelta(t, color.GrayMedium, texture.ColorAt(surfaceAtPoint(0, 0, 0)))
+	assertColorsVary(t, texture)
+}
+
+func TestNoise_ShouldBeDeterministicForSameSeed(t *testing.T) {
+	first := textures.NewNoise(noise.NewPerlin(SEED), 4, color.Black, color.White)
+	second := textures.NewNoise(noise.NewPerlin(SEED), 4, color.Black, color.White)
+
+	surface := surfaceAtPoint(0.3, 1.7, -2.2)
+	assert.Equal(t, first.ColorAt(surface), second.ColorAt(surface))
+}
+
+func TestNoise_ShouldPanic_IfSettingsInvalid(t *testing.T) {
+	assert.Panics(t, func() { textures.NewNoise(nil, 1, color.Black, color.White) })
+	assert.Panics(t, func() { textures.NewNoise(noise.NewPerlin(SEED), 0, color.Black, color.White) })
+}
+
+func surfaceAtPoint(x, y, z core.Real) core.SurfacePoint {
+	return core.SurfacePoint{Point: core.NewVec3(x, y, z)}
+}
+
+func assertColorsVary(t *testing.T, texture textures.Texture) {
+	minimum, maximum := core.Inf(), -core.Inf()
+	for i := 0; i < 100; i++ {
+		x := core.Real(i)
+		red := texture.ColorAt(surfaceAtPoint(0.13*x, 0.07*x, -0.11*x)).R()
+		minimum, maximum = core.Min(minimum, red), core.Max(maximum, red)
+	}
+	assert.Greater(t, maximum-minimum, core.Real(0.2))
+}
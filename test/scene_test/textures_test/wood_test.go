@@ -0,0 +1,28 @@
+package textures_test
+
+import (
+	"testing"
+
+	"github.com/Shamanskiy/go-ray-tracer/src/core/color"
+	"github.com/Shamanskiy/go-ray-tracer/src/core/noise"
+	"github.com/Shamanskiy/go-ray-tracer/src/scene/textures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWood_ShouldHaveRingsAroundYAxis_WithoutTurbulence(t *testing.T) {
+	texture := textures.NewWood(noise.NewPerlin(SEED), 2, 0, color.White, color.Black)
+
+	assertColorInDelta(t, color.White, texture.ColorAt(surfaceAtPoint(0, 5, 0)))
+	assertColorInDelta(t, color.GrayMedium, texture.ColorAt(surfaceAtPoint(0.25, 5, 0)))
+	assertColorInDelta(t, color.GrayMedium, texture.ColorAt(surfaceAtPoint(0, -3, 1.25)))
+}
+
+func TestWood_ShouldVaryWithTurbulence(t *testing.T) {
+	texture := textures.NewWood(noise.NewPerlin(SEED), 2, 1, color.White, color.Black)
+
+	assertColorsVary(t, texture)
+}
+
+func TestWood_ShouldPanic_IfScaleNotPositive(t *testing.T) {
+	assert.Panics(t, func() { textures.NewWood(noise.NewPerlin(SEED), -2, 0, color.White, color.Black) })
+}
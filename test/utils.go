@@ -7,7 +7,11 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func AssertInDeltaVec3(t *testing.T, expected core.Vec3, result core.Vec3, delta float32) {
+func AssertInDeltaVec3(t *testing.T, expected core.Vec3, result core.Vec3, delta core.Real) {
+	assert.True(t, expected.InDelta(result, delta), "expected %v, got %v, tolerance %v", expected, result, delta)
+}
+
+func AssertInDeltaVec2(t *testing.T, expected core.Vec2, result core.Vec2, delta core.Real) {
 	assert.True(t, expected.InDelta(result, delta), "expected %v, got %v, tolerance %v", expected, result, delta)
 }
 